@@ -0,0 +1,224 @@
+package redisCache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// ttlEnvelope is how AutoExtendStore stores a value together with its original TTL.
+type ttlEnvelope struct {
+	Value string        `json:"value"`
+	TTL   time.Duration `json:"ttl"`
+}
+
+// AutoExtendStore is a cache.Store that slides the expiry of items as they are read.
+type AutoExtendStore struct {
+	cache.Store
+	redis           *Redis
+	threshold       float64
+	extensionFactor float64
+}
+
+// NewAutoExtend Wrap inner so that reading an item whose remaining TTL has dropped below
+// threshold × its original TTL extends it by extensionFactor × the original TTL.
+// A threshold or extensionFactor that is not positive defaults to 0.25 and 1.
+// Items are stored as a JSON envelope carrying the original TTL. Expiry is only extended
+// when inner is a store created by New; other stores just have their values unwrapped.
+func NewAutoExtend(inner cache.Store, threshold float64, extensionFactor float64) cache.Store {
+	if threshold <= 0 {
+		threshold = 0.25
+	}
+	if extensionFactor <= 0 {
+		extensionFactor = 1
+	}
+	r, _ := inner.(*Redis)
+	return &AutoExtendStore{
+		Store:           inner,
+		redis:           r,
+		threshold:       threshold,
+		extensionFactor: extensionFactor,
+	}
+}
+
+func (s *AutoExtendStore) WithContext(ctx context.Context) cache.Store {
+	return NewAutoExtend(s.Store.WithContext(ctx), s.threshold, s.extensionFactor)
+}
+
+// Get Retrieve an item from the cache by key, extending its TTL when it is about to expire.
+func (s *AutoExtendStore) Get(key string, def interface{}) interface{} {
+	val, _, ok := s.GetWithTTL(key)
+	if !ok {
+		if fn, isFn := def.(func() interface{}); isFn {
+			return fn()
+		}
+		return def
+	}
+
+	return val
+}
+
+// GetWithTTL Retrieve an item and its remaining TTL, extending the TTL when it is about to expire.
+// The remaining TTL is only known when the wrapped store is created by New.
+func (s *AutoExtendStore) GetWithTTL(key string) (interface{}, time.Duration, bool) {
+	if s.redis == nil {
+		raw := s.Store.Get(key, nil)
+		if raw == nil {
+			return nil, 0, false
+		}
+		val, _, _ := unwrapTTL(raw)
+		return val, 0, true
+	}
+
+	r := s.redis
+	var get *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := r.Redis.Pipelined(r.ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(r.ctx, r.Prefix+key)
+		pttl = pipe.PTTL(r.ctx, r.Prefix+key)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, false
+	}
+
+	val, original, wrapped := unwrapTTL(decodeValue(get.Val()))
+	remaining := pttl.Val()
+	if wrapped && original > 0 && remaining > 0 && float64(remaining) < s.threshold*float64(original) {
+		extended := remaining + time.Duration(s.extensionFactor*float64(original))
+		if raw, err := r.getEx(r.ctx, key, extended); err == nil {
+			val, _, _ = unwrapTTL(decodeValue(raw))
+			remaining = extended
+		}
+	}
+
+	return val, remaining, true
+}
+
+func (s *AutoExtendStore) GetBool(key string, def bool) bool {
+	switch s.Get(key, def) {
+	case "1", "true", true:
+		return true
+	case "0", "false", false:
+		return false
+	}
+
+	return def
+}
+
+func (s *AutoExtendStore) GetInt(key string, def int) int {
+	switch res := s.Get(key, def).(type) {
+	case int:
+		return res
+	case string:
+		if i, err := strconv.Atoi(res); err == nil {
+			return i
+		}
+	}
+
+	return def
+}
+
+func (s *AutoExtendStore) GetString(key string, def string) string {
+	if res, ok := s.Get(key, def).(string); ok {
+		return res
+	}
+
+	return def
+}
+
+// Put Store an item in the cache for a given number of seconds.
+func (s *AutoExtendStore) Put(key string, value interface{}, seconds time.Duration) error {
+	payload, err := wrapTTL(value, seconds)
+	if err != nil {
+		return err
+	}
+
+	return s.Store.Put(key, payload, seconds)
+}
+
+// Pull Retrieve an item from the cache and delete it.
+func (s *AutoExtendStore) Pull(key string, def interface{}) interface{} {
+	raw := s.Store.Pull(key, nil)
+	if raw == nil {
+		return def
+	}
+	val, _, _ := unwrapTTL(raw)
+
+	return val
+}
+
+// Add Store an item in the cache if the key does not exist.
+func (s *AutoExtendStore) Add(key string, value interface{}, seconds time.Duration) bool {
+	payload, err := wrapTTL(value, seconds)
+	if err != nil {
+		return false
+	}
+
+	return s.Store.Add(key, payload, seconds)
+}
+
+// Remember Get an item from the cache, or execute the given Closure and store the result.
+func (s *AutoExtendStore) Remember(key string, ttl time.Duration, callback func() interface{}) (interface{}, error) {
+	if val := s.Get(key, nil); val != nil {
+		return val, nil
+	}
+	val := callback()
+	if err := s.Put(key, val, ttl); err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
+
+// RememberForever Get an item from the cache, or execute the given Closure and store the result forever.
+func (s *AutoExtendStore) RememberForever(key string, callback func() interface{}) (interface{}, error) {
+	return s.Remember(key, 0, callback)
+}
+
+// Forever Store an item in the cache indefinitely.
+func (s *AutoExtendStore) Forever(key string, value interface{}) bool {
+	return s.Put(key, value, 0) == nil
+}
+
+// wrapTTL Encode value and its TTL as a JSON envelope.
+func wrapTTL(value interface{}, ttl time.Duration) (string, error) {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case bool:
+		str = "0"
+		if v {
+			str = "1"
+		}
+	default:
+		str = fmt.Sprint(v)
+	}
+	payload, err := json.Marshal(ttlEnvelope{Value: str, TTL: ttl})
+
+	return string(payload), err
+}
+
+// unwrapTTL Decode a JSON envelope written by wrapTTL. Values that are not envelopes
+// are returned unchanged with wrapped set to false.
+func unwrapTTL(raw interface{}) (value interface{}, ttl time.Duration, wrapped bool) {
+	str, ok := raw.(string)
+	if !ok || !strings.HasPrefix(str, `{"value":`) {
+		return raw, 0, false
+	}
+	var env ttlEnvelope
+	if err := json.Unmarshal([]byte(str), &env); err != nil {
+		return raw, 0, false
+	}
+
+	return env.Value, env.TTL, true
+}
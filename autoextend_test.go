@@ -0,0 +1,55 @@
+package redisCache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoExtendBelowThreshold(t *testing.T) {
+	r, m := newTestStore(t)
+	s := NewAutoExtend(r, 0.5, 2).(*AutoExtendStore)
+
+	if err := s.Put("k", "v", time.Minute); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	m.FastForward(40 * time.Second)
+
+	val, ttl, ok := s.GetWithTTL("k")
+	if !ok || val != "v" {
+		t.Fatalf("GetWithTTL = %v, %v, want v, true", val, ok)
+	}
+	want := 20*time.Second + 2*time.Minute
+	if ttl != want || m.TTL("k") != want {
+		t.Fatalf("TTL = %v (server %v), want %v", ttl, m.TTL("k"), want)
+	}
+}
+
+func TestAutoExtendAboveThreshold(t *testing.T) {
+	r, m := newTestStore(t)
+	s := NewAutoExtend(r, 0.5, 2).(*AutoExtendStore)
+
+	if err := s.Put("k", "v", time.Minute); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	m.FastForward(10 * time.Second)
+
+	if got := s.GetString("k", ""); got != "v" {
+		t.Fatalf("GetString = %q, want v", got)
+	}
+	if got := m.TTL("k"); got != 50*time.Second {
+		t.Fatalf("TTL = %v, want 50s", got)
+	}
+}
+
+func TestAutoExtendNonRedisStore(t *testing.T) {
+	r, _ := newTestStore(t)
+	inner := struct{ *Redis }{r}
+	s := NewAutoExtend(inner, 0.5, 2)
+
+	if err := s.Put("k", "v", time.Minute); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := s.Get("k", nil); got != "v" {
+		t.Fatalf("Get = %v, want v", got)
+	}
+}
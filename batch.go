@@ -0,0 +1,163 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+type batchEntry struct {
+	key   string
+	value interface{}
+	ttl   time.Duration
+}
+
+// BatchError collects the errors of several failed flushes.
+type BatchError []error
+
+func (e BatchError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return "redisCache: " + strings.Join(msgs, "; ")
+}
+
+// BatchWriter buffers Puts and writes them to Redis in a single pipeline.
+type BatchWriter struct {
+	MaxSize       int
+	FlushInterval time.Duration
+
+	store   *Redis
+	mu      sync.Mutex
+	flushMu sync.Mutex
+	entries []batchEntry
+	errs    []error
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewBatchWriter Create a BatchWriter that flushes once maxSize entries are buffered
+// or every flushInterval, whichever comes first. A zero flushInterval disables timed flushes.
+// The store must be created by New; with other stores Flush returns ErrUnsupportedStore.
+func NewBatchWriter(store cache.Store, maxSize int, flushInterval time.Duration) *BatchWriter {
+	r, _ := store.(*Redis)
+	w := &BatchWriter{
+		MaxSize:       maxSize,
+		FlushInterval: flushInterval,
+		store:         r,
+		done:          make(chan struct{}),
+	}
+	if flushInterval > 0 {
+		go w.loop()
+	}
+
+	return w
+}
+
+// Write Buffer an item to be stored for the given duration.
+// Errors from automatic flushes are reported by the next call to Flush.
+func (w *BatchWriter) Write(key string, value interface{}, ttl time.Duration) {
+	w.mu.Lock()
+	w.entries = append(w.entries, batchEntry{key: key, value: value, ttl: ttl})
+	full := w.MaxSize > 0 && len(w.entries) >= w.MaxSize
+	w.mu.Unlock()
+
+	if full {
+		w.autoFlush()
+	}
+}
+
+// Flush Write all buffered items in a single pipeline. Flushes never overlap, so items
+// are written in the order they were buffered. The error of the pipeline and of every
+// automatic flush that failed since the last call are returned together: a single error
+// as is, several as a BatchError.
+func (w *BatchWriter) Flush(ctx context.Context) error {
+	err := w.flush(ctx)
+
+	w.mu.Lock()
+	errs := w.errs
+	w.errs = nil
+	w.mu.Unlock()
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return BatchError(errs)
+	}
+}
+
+func (w *BatchWriter) flush(ctx context.Context) error {
+	if w.store == nil {
+		return ErrUnsupportedStore
+	}
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	entries := w.entries
+	w.entries = nil
+	w.mu.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	_, err := w.store.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, e := range entries {
+			pipe.Set(ctx, w.store.Prefix+e.key, w.store.encodeValue(e.value), e.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		w.store.negative.Delete(e.key)
+	}
+
+	return nil
+}
+
+// Close Stop timed flushes and write any remaining buffered items.
+func (w *BatchWriter) Close(ctx context.Context) error {
+	w.once.Do(func() {
+		close(w.done)
+	})
+
+	return w.Flush(ctx)
+}
+
+func (w *BatchWriter) loop() {
+	ticker := time.NewTicker(w.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.autoFlush()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *BatchWriter) autoFlush() {
+	if w.store == nil {
+		return
+	}
+	if err := w.flush(w.store.ctx); err != nil {
+		w.mu.Lock()
+		w.errs = append(w.errs, err)
+		w.mu.Unlock()
+	}
+}
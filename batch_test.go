@@ -0,0 +1,56 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+func TestBatchWriterFlush(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	w := NewBatchWriter(r, 0, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		w.Write("k"+strconv.Itoa(i), i, 0)
+	}
+	if len(m.Keys()) != 0 {
+		t.Fatal("items were written before Flush")
+	}
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if n := len(m.Keys()); n != 100 {
+		t.Fatalf("%d keys written, want 100", n)
+	}
+	if got := r.GetInt("k42", 0); got != 42 {
+		t.Fatalf("k42 = %d, want 42", got)
+	}
+}
+
+func TestBatchWriterReportsEveryFailedFlush(t *testing.T) {
+	r, m := newTestStore(t)
+	stubCommands(m).on("SET", func(c *server.Peer, args []string) { c.WriteError("OOM command not allowed") })
+	w := NewBatchWriter(r, 1, 0)
+
+	w.Write("a", 1, 0)
+	w.Write("b", 2, 0)
+	err := w.Flush(context.Background())
+	errs, ok := err.(BatchError)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Flush = %v, want a BatchError of 2 errors", err)
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("second Flush = %v, want nil", err)
+	}
+}
+
+func TestBatchWriterUnsupportedStore(t *testing.T) {
+	w := NewBatchWriter(nil, 0, 0)
+	w.Write("a", 1, 0)
+	if err := w.Flush(context.Background()); err != ErrUnsupportedStore {
+		t.Fatalf("Flush = %v, want ErrUnsupportedStore", err)
+	}
+}
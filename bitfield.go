@@ -0,0 +1,22 @@
+package redisCache
+
+import (
+	"context"
+)
+
+// BitField Treat the string at key as an array of arbitrary-width integers and run the given
+// GET, SET, INCRBY and OVERFLOW subcommands on it, e.g. "INCRBY", "u8", 0, 1.
+func (r *Redis) BitField(ctx context.Context, key string, args ...interface{}) ([]int64, error) {
+	return r.Redis.BitField(ctx, r.Prefix+key, args...).Result()
+}
+
+// BitFieldRO Run read-only GET subcommands on the bitfield at key. BITFIELD_RO can be served
+// by read replicas; servers older than Redis 6.0 get an equivalent BITFIELD instead.
+func (r *Redis) BitFieldRO(ctx context.Context, key string, args ...interface{}) ([]int64, error) {
+	if !r.features.BitFieldRO {
+		return r.BitField(ctx, key, args...)
+	}
+	cmdArgs := append([]interface{}{"bitfield_ro", r.Prefix + key}, args...)
+
+	return r.Redis.Do(ctx, cmdArgs...).Int64Slice()
+}
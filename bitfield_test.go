@@ -0,0 +1,61 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// stubBitField Answer BITFIELD and BITFIELD_RO with one counter per subcommand, numbered from 1.
+func stubBitField(s *stubs) {
+	reply := func(c *server.Peer, args []string) {
+		n := 0
+		for _, arg := range args {
+			switch arg {
+			case "GET", "SET", "INCRBY":
+				n++
+			}
+		}
+		c.WriteLen(n)
+		for i := 1; i <= n; i++ {
+			c.WriteInt(i)
+		}
+	}
+	s.on("BITFIELD", reply)
+	s.on("BITFIELD_RO", reply)
+}
+
+func TestBitField(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	stubBitField(s)
+
+	res, err := r.BitField(context.Background(), "flags", "INCRBY", "u8", 0, 1, "GET", "u4", 8)
+	if err != nil || len(res) != 2 || res[1] != 2 {
+		t.Fatalf("BitField = %v, %v", res, err)
+	}
+	equalArgs(t, s.lastCall(t, "BITFIELD"), "app:flags", "INCRBY", "u8", "0", "1", "GET", "u4", "8")
+}
+
+func TestBitFieldRO(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	stubBitField(s)
+	ctx := context.Background()
+
+	res, err := r.BitFieldRO(ctx, "flags", "GET", "u8", 0)
+	if err != nil || len(res) != 1 || res[0] != 1 {
+		t.Fatalf("BitFieldRO = %v, %v", res, err)
+	}
+	equalArgs(t, s.lastCall(t, "BITFIELD_RO"), "app:flags", "GET", "u8", "0")
+
+	r.features.BitFieldRO = false
+	if _, err := r.BitFieldRO(ctx, "flags", "GET", "u4", 8); err != nil {
+		t.Fatalf("BitFieldRO fallback: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "BITFIELD"), "app:flags", "GET", "u4", "8")
+	if n := len(s.called("BITFIELD_RO")); n != 1 {
+		t.Fatalf("BITFIELD_RO sent %d times, want only before the fallback", n)
+	}
+}
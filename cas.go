@@ -0,0 +1,98 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CompareAndSwap Set key to newValue only if its current value equals expected.
+// The check and the write run under WATCH+MULTI/EXEC; when a concurrent write aborts the
+// transaction it is retried up to Config.CASRetries times. It returns false when the
+// value did not match or kept changing.
+func (r *Redis) CompareAndSwap(ctx context.Context, name string, expected, newValue string, ttl time.Duration) (bool, error) {
+	key := r.Prefix + name
+	for i := 0; i < r.config.CASRetries; i++ {
+		swapped := false
+		err := r.Redis.Watch(ctx, func(tx *redis.Tx) error {
+			current, err := tx.Get(ctx, key).Result()
+			if err == redis.Nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if decodeValue(current) != expected {
+				return nil
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, r.encodeValue(newValue), ttl)
+				return nil
+			})
+			if err == nil {
+				swapped = true
+			}
+			return r.afterTx(ctx, tx, err)
+		}, key)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if swapped {
+			r.negative.Delete(name)
+		}
+
+		return swapped, nil
+	}
+
+	return false, nil
+}
+
+// AtomicUpdate Replace the value of key with the result of fn applied to its current value.
+// A missing key is passed to fn as an empty string. If a concurrent write aborts the
+// transaction, fn is called again with the fresh value, up to Config.CASRetries times,
+// after which redis.TxFailedErr is returned. Errors from fn abort the update unchanged.
+func (r *Redis) AtomicUpdate(ctx context.Context, name string, ttl time.Duration, fn func(current string) (next string, err error)) error {
+	key := r.Prefix + name
+	for i := 0; i < r.config.CASRetries; i++ {
+		err := r.Redis.Watch(ctx, func(tx *redis.Tx) error {
+			current, err := tx.Get(ctx, key).Result()
+			if err != nil && err != redis.Nil {
+				return err
+			}
+			next, err := fn(decodeValue(current))
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, r.encodeValue(next), ttl)
+				return nil
+			})
+			return r.afterTx(ctx, tx, err)
+		}, key)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err == nil {
+			r.negative.Delete(name)
+		}
+
+		return err
+	}
+
+	return redis.TxFailedErr
+}
+
+// afterTx Reset the connection of tx when its transaction failed with anything other than
+// a concurrent write, so it goes back to the pool without leftover MULTI state, and return err.
+// Servers without RESET are left alone: the client discards connections broken mid-command.
+func (r *Redis) afterTx(ctx context.Context, tx *redis.Tx, err error) error {
+	if err != nil && err != redis.TxFailedErr && r.features.Reset {
+		_ = r.resetConn(ctx, tx)
+	}
+
+	return err
+}
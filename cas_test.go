@@ -0,0 +1,127 @@
+package redisCache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+
+	if ok, err := r.CompareAndSwap(ctx, "k", "a", "b", 0); err != nil || ok {
+		t.Fatalf("CompareAndSwap on missing key = %v, %v", ok, err)
+	}
+	_ = r.Put("k", "a", 0)
+	if ok, err := r.CompareAndSwap(ctx, "k", "x", "b", 0); err != nil || ok {
+		t.Fatalf("CompareAndSwap with stale value = %v, %v", ok, err)
+	}
+	if ok, err := r.CompareAndSwap(ctx, "k", "a", "b", 0); err != nil || !ok {
+		t.Fatalf("CompareAndSwap = %v, %v", ok, err)
+	}
+	if got := r.GetString("k", ""); got != "b" {
+		t.Fatalf("value = %q, want b", got)
+	}
+}
+
+func TestCompareAndSwapConcurrent(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+	_ = r.Put("k", "0", 0)
+
+	var wins int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := r.CompareAndSwap(ctx, "k", "0", strconv.Itoa(i+1), 0)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				atomic.AddInt32(&wins, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if wins != 1 {
+		t.Fatalf("%d swaps succeeded, want exactly 1", wins)
+	}
+	if got := r.GetString("k", "0"); got == "0" {
+		t.Fatal("value was not swapped")
+	}
+}
+
+func TestAtomicUpdateRetriesOnConflict(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+	_ = r.Put("k", "1", 0)
+
+	var seen []string
+	err := r.AtomicUpdate(ctx, "k", 0, func(current string) (string, error) {
+		seen = append(seen, current)
+		if len(seen) == 1 {
+			r.Redis.Set(ctx, "k", "10", 0)
+		}
+		n, _ := strconv.Atoi(current)
+		return strconv.Itoa(n + 1), nil
+	})
+	if err != nil {
+		t.Fatalf("AtomicUpdate: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "1" || seen[1] != "10" {
+		t.Fatalf("fn saw %q, want it called again with the concurrent write", seen)
+	}
+	if got := r.GetString("k", ""); got != "11" {
+		t.Fatalf("value = %q, want 11", got)
+	}
+}
+
+func TestAtomicUpdateGivesUp(t *testing.T) {
+	r, _ := newTestStore(t, Config{CASRetries: 2})
+	ctx := context.Background()
+	_ = r.Put("k", "1", 0)
+
+	calls := 0
+	err := r.AtomicUpdate(ctx, "k", 0, func(current string) (string, error) {
+		calls++
+		r.Redis.Set(ctx, "k", "other"+strconv.Itoa(calls), 0)
+		return "mine", nil
+	})
+	if err != redis.TxFailedErr {
+		t.Fatalf("AtomicUpdate = %v, want redis.TxFailedErr", err)
+	}
+	if calls != 2 {
+		t.Fatalf("fn called %d times, want CASRetries = 2", calls)
+	}
+	if got := r.GetString("k", ""); got != "other2" {
+		t.Fatalf("value = %q, want the concurrent write kept", got)
+	}
+}
+
+func TestAtomicUpdateFnError(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+	_ = r.Put("k", "1", 0)
+	errBoom := errors.New("boom")
+
+	calls := 0
+	err := r.AtomicUpdate(ctx, "k", 0, func(current string) (string, error) {
+		calls++
+		return "2", errBoom
+	})
+	if err != errBoom || calls != 1 {
+		t.Fatalf("AtomicUpdate = %v after %d calls, want the error of fn after 1", err, calls)
+	}
+	if got := r.GetString("k", ""); got != "1" {
+		t.Fatalf("value = %q, want it unchanged", got)
+	}
+}
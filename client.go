@@ -0,0 +1,105 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ClientPause Suspend command processing for all clients for d.
+// mode is "ALL", the default, or "WRITE" to only suspend writes (Redis 6.2+).
+func (r *Redis) ClientPause(ctx context.Context, d time.Duration, mode ...string) error {
+	args := []interface{}{"client", "pause", d.Milliseconds()}
+	if len(mode) > 0 && mode[0] != "" {
+		args = append(args, mode[0])
+	}
+
+	return r.Redis.Do(ctx, args...).Err()
+}
+
+// ClientUnpause Resume command processing suspended by ClientPause. Requires Redis 6.2 or later.
+func (r *Redis) ClientUnpause(ctx context.Context) error {
+	return r.Redis.Do(ctx, "client", "unpause").Err()
+}
+
+// ClientNoEvict Protect the connection running the command from client eviction under memory pressure.
+// The flag belongs to a single pooled connection; use a dedicated connection from Redis.Conn
+// for long-lived admin sessions. Requires Redis 7.0 or later.
+func (r *Redis) ClientNoEvict(ctx context.Context, on bool) error {
+	return r.Redis.Do(ctx, "client", "no-evict", onOff(on)).Err()
+}
+
+// onOff Convert a flag into the ON/OFF argument used by CLIENT subcommands.
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+
+	return "off"
+}
+
+// ClientNoTouch Stop reads on the connection from updating the LRU/LFU access time of keys,
+// so auditing reads do not change eviction order. Like other CLIENT flags it is scoped to
+// the connection that runs it. Requires Redis 7.2 or later.
+func (r *Redis) ClientNoTouch(ctx context.Context, on bool) error {
+	return r.Redis.Do(ctx, "client", "no-touch", onOff(on)).Err()
+}
+
+// ClientCaching Control whether the keys read by the next command on conn are tracked for
+// server-assisted client-side caching. Only valid once tracking is enabled on the same
+// connection with OptIn (yes) or OptOut (no); see ClientTracking. The flag only applies to
+// the connection it is sent on, so it takes conn rather than running on the pool.
+func (r *Redis) ClientCaching(ctx context.Context, conn *redis.Conn, yes bool) error {
+	arg := "no"
+	if yes {
+		arg = "yes"
+	}
+
+	return conn.Process(ctx, redis.NewStatusCmd(ctx, "client", "caching", arg))
+}
+
+// TrackingOptions configures CLIENT TRACKING.
+type TrackingOptions struct {
+	On bool
+	// Redirect sends invalidation messages to the connection with this client ID.
+	// It is required with RESP2, which the underlying client speaks.
+	Redirect int64
+	// Prefixes limits broadcasting mode to keys with these prefixes. The store prefix is applied.
+	Prefixes []string
+	BCast    bool
+	OptIn    bool
+	OptOut   bool
+	NoLoop   bool
+}
+
+// ClientTracking Enable or disable server-assisted client-side caching for conn, a dedicated
+// connection obtained from Redis.Conn. Tracking belongs to a single connection, so it cannot
+// be enabled on the pool. Invalidations are delivered on the __redis__:invalidate channel of
+// the Redirect connection. Nil opts disable tracking.
+func (r *Redis) ClientTracking(ctx context.Context, conn *redis.Conn, opts *TrackingOptions) error {
+	if opts == nil {
+		opts = &TrackingOptions{}
+	}
+	args := []interface{}{"client", "tracking", onOff(opts.On)}
+	if opts.Redirect != 0 {
+		args = append(args, "redirect", opts.Redirect)
+	}
+	for _, prefix := range opts.Prefixes {
+		args = append(args, "prefix", r.Prefix+prefix)
+	}
+	if opts.BCast {
+		args = append(args, "bcast")
+	}
+	if opts.OptIn {
+		args = append(args, "optin")
+	}
+	if opts.OptOut {
+		args = append(args, "optout")
+	}
+	if opts.NoLoop {
+		args = append(args, "noloop")
+	}
+
+	return conn.Process(ctx, redis.NewStatusCmd(ctx, args...))
+}
@@ -0,0 +1,113 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// stubClient Create a store whose server accepts every CLIENT subcommand.
+func stubClient(t *testing.T) (*Redis, *stubs) {
+	t.Helper()
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("CLIENT", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	return r, s
+}
+
+func TestClientPause(t *testing.T) {
+	r, s := stubClient(t)
+	ctx := context.Background()
+
+	if err := r.ClientPause(ctx, 1500*time.Millisecond); err != nil {
+		t.Fatalf("ClientPause: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "pause", "1500")
+	if err := r.ClientPause(ctx, time.Second, "WRITE"); err != nil {
+		t.Fatalf("ClientPause WRITE: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "pause", "1000", "WRITE")
+	if err := r.ClientUnpause(ctx); err != nil {
+		t.Fatalf("ClientUnpause: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "unpause")
+}
+
+func TestClientNoEvict(t *testing.T) {
+	r, s := stubClient(t)
+	ctx := context.Background()
+
+	if err := r.ClientNoEvict(ctx, true); err != nil {
+		t.Fatalf("ClientNoEvict: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "no-evict", "on")
+	if err := r.ClientNoEvict(ctx, false); err != nil {
+		t.Fatalf("ClientNoEvict off: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "no-evict", "off")
+}
+
+func TestClientNoTouch(t *testing.T) {
+	r, s := stubClient(t)
+	ctx := context.Background()
+
+	if err := r.ClientNoTouch(ctx, true); err != nil {
+		t.Fatalf("ClientNoTouch: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "no-touch", "on")
+	if err := r.ClientNoTouch(ctx, false); err != nil {
+		t.Fatalf("ClientNoTouch off: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "no-touch", "off")
+}
+
+func TestClientCaching(t *testing.T) {
+	r, s := stubClient(t)
+	ctx := context.Background()
+	conn := r.Redis.Conn(ctx)
+	defer conn.Close()
+
+	if err := conn.Ping(ctx).Err(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if err := r.ClientCaching(ctx, conn, true); err != nil {
+		t.Fatalf("ClientCaching: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "caching", "yes")
+	if s.lastPeer(t, "CLIENT") != s.lastPeer(t, "PING") {
+		t.Fatal("CLIENT CACHING was not sent on the given connection")
+	}
+	if err := r.ClientCaching(ctx, conn, false); err != nil {
+		t.Fatalf("ClientCaching no: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "caching", "no")
+}
+
+func TestClientTracking(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	s.on("CLIENT", func(c *server.Peer, args []string) { c.WriteOK() })
+	ctx := context.Background()
+	conn := r.Redis.Conn(ctx)
+	defer conn.Close()
+
+	opts := &TrackingOptions{On: true, Redirect: 7, Prefixes: []string{"user:"}, BCast: true, NoLoop: true}
+	if err := r.ClientTracking(ctx, conn, opts); err != nil {
+		t.Fatalf("ClientTracking: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "tracking", "on", "redirect", "7", "prefix", "app:user:", "bcast", "noloop")
+	if err := conn.Ping(ctx).Err(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if s.lastPeer(t, "CLIENT") != s.lastPeer(t, "PING") {
+		t.Fatal("CLIENT TRACKING was not sent on the given connection")
+	}
+
+	if err := r.ClientTracking(ctx, conn, nil); err != nil {
+		t.Fatalf("ClientTracking with nil options: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLIENT"), "tracking", "off")
+}
@@ -0,0 +1,64 @@
+package redisCache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNegativeDuration is returned by ManualClock.Advance when asked to move backwards.
+var ErrNegativeDuration = errors.New("redisCache: clock cannot move backwards")
+
+// Clock is the source of time used by the store for its local bookkeeping.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock is a Clock backed by the system time.
+type RealClock struct{}
+
+// Now Return the current system time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep Pause the current goroutine for d.
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// ManualClock is a Clock that only moves when advanced, for deterministic tests.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock Create a ManualClock starting at start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now Return the current virtual time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep Advance the virtual time by d without blocking.
+func (c *ManualClock) Sleep(d time.Duration) {
+	_ = c.Advance(d)
+}
+
+// Advance Move the virtual time forward by d.
+func (c *ManualClock) Advance(d time.Duration) error {
+	if d < 0 {
+		return ErrNegativeDuration
+	}
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ClusterMyID Retrieve the 40-character ID of the connected cluster node.
+func (r *Redis) ClusterMyID(ctx context.Context) (string, error) {
+	return r.Redis.Do(ctx, "cluster", "myid").Text()
+}
+
+// ClusterMeet Connect the node to the cluster node listening at host:port.
+func (r *Redis) ClusterMeet(ctx context.Context, host string, port int) error {
+	if err := validateAddress(host, port); err != nil {
+		return err
+	}
+
+	return r.Redis.ClusterMeet(ctx, host, strconv.Itoa(port)).Err()
+}
+
+// ClusterForget Remove the node nodeID from the node table of the connected node.
+func (r *Redis) ClusterForget(ctx context.Context, nodeID string) error {
+	myID, err := r.ClusterMyID(ctx)
+	if err != nil {
+		return err
+	}
+	if myID == nodeID {
+		return ErrForgetSelf
+	}
+
+	return r.Redis.ClusterForget(ctx, nodeID).Err()
+}
+
+// ClusterAddSlots Assign the given hash slots to the connected node.
+func (r *Redis) ClusterAddSlots(ctx context.Context, slots ...int) error {
+	return r.Redis.ClusterAddSlots(ctx, slots...).Err()
+}
+
+// ClusterDelSlots Unassign the given hash slots from the connected node.
+func (r *Redis) ClusterDelSlots(ctx context.Context, slots ...int) error {
+	return r.Redis.ClusterDelSlots(ctx, slots...).Err()
+}
+
+// ClusterSetSlot Change the state of a hash slot on the connected node.
+// state is MIGRATING, IMPORTING or NODE, which refer to nodeID, or STABLE, which ignores it.
+func (r *Redis) ClusterSetSlot(ctx context.Context, slot int, state string, nodeID string) error {
+	state = strings.ToUpper(state)
+	switch state {
+	case "MIGRATING", "IMPORTING", "NODE":
+		return r.Redis.Do(ctx, "cluster", "setslot", slot, state, nodeID).Err()
+	case "STABLE":
+		return r.Redis.Do(ctx, "cluster", "setslot", slot, state).Err()
+	}
+
+	return ErrInvalidSlotState
+}
+
+// ClusterReplicate Make the connected node a replica of the master masterNodeID.
+func (r *Redis) ClusterReplicate(ctx context.Context, masterNodeID string) error {
+	return r.Redis.ClusterReplicate(ctx, masterNodeID).Err()
+}
+
+// ClusterSaveConfig Persist the cluster configuration of the connected node to nodes.conf.
+func (r *Redis) ClusterSaveConfig(ctx context.Context) error {
+	return r.Redis.ClusterSaveConfig(ctx).Err()
+}
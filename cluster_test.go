@@ -0,0 +1,117 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+const testNodeID = "07c37dfeb235213a872192d90877d0cd55635b91"
+
+// stubCluster Create a store whose server answers CLUSTER subcommands as the node testNodeID.
+func stubCluster(t *testing.T) (*Redis, *stubs) {
+	t.Helper()
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("CLUSTER", func(c *server.Peer, args []string) {
+		if strings.EqualFold(args[0], "myid") {
+			c.WriteBulk(testNodeID)
+			return
+		}
+		c.WriteOK()
+	})
+
+	return r, s
+}
+
+func TestClusterMyID(t *testing.T) {
+	r, s := stubCluster(t)
+
+	id, err := r.ClusterMyID(context.Background())
+	if err != nil || id != testNodeID {
+		t.Fatalf("ClusterMyID = %q, %v", id, err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "myid")
+}
+
+func TestClusterMeet(t *testing.T) {
+	r, s := stubCluster(t)
+	ctx := context.Background()
+
+	if err := r.ClusterMeet(ctx, "10.0.0.2", 7001); err != nil {
+		t.Fatalf("ClusterMeet: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "meet", "10.0.0.2", "7001")
+	if err := r.ClusterMeet(ctx, "10.0.0.2", 70000); err != ErrInvalidAddress {
+		t.Fatalf("ClusterMeet with an invalid port = %v, want ErrInvalidAddress", err)
+	}
+	if err := r.ClusterMeet(ctx, " ", 7001); err != ErrInvalidAddress {
+		t.Fatalf("ClusterMeet with an empty host = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func TestClusterForget(t *testing.T) {
+	r, s := stubCluster(t)
+	ctx := context.Background()
+	other := "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca"
+
+	if err := r.ClusterForget(ctx, other); err != nil {
+		t.Fatalf("ClusterForget: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "forget", other)
+	if err := r.ClusterForget(ctx, testNodeID); err != ErrForgetSelf {
+		t.Fatalf("ClusterForget of the connected node = %v, want ErrForgetSelf", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "myid")
+}
+
+func TestClusterAddDelSlots(t *testing.T) {
+	r, s := stubCluster(t)
+	ctx := context.Background()
+
+	if err := r.ClusterAddSlots(ctx, 1, 2, 3); err != nil {
+		t.Fatalf("ClusterAddSlots: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "addslots", "1", "2", "3")
+	if err := r.ClusterDelSlots(ctx, 2); err != nil {
+		t.Fatalf("ClusterDelSlots: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "delslots", "2")
+}
+
+func TestClusterSetSlot(t *testing.T) {
+	r, s := stubCluster(t)
+	ctx := context.Background()
+
+	if err := r.ClusterSetSlot(ctx, 42, "migrating", testNodeID); err != nil {
+		t.Fatalf("ClusterSetSlot: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "setslot", "42", "MIGRATING", testNodeID)
+	if err := r.ClusterSetSlot(ctx, 42, "STABLE", testNodeID); err != nil {
+		t.Fatalf("ClusterSetSlot stable: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "setslot", "42", "STABLE")
+	if err := r.ClusterSetSlot(ctx, 42, "moving", testNodeID); err != ErrInvalidSlotState {
+		t.Fatalf("ClusterSetSlot with an unknown state = %v, want ErrInvalidSlotState", err)
+	}
+}
+
+func TestClusterReplicate(t *testing.T) {
+	r, s := stubCluster(t)
+
+	if err := r.ClusterReplicate(context.Background(), testNodeID); err != nil {
+		t.Fatalf("ClusterReplicate: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "replicate", testNodeID)
+}
+
+func TestClusterSaveConfig(t *testing.T) {
+	r, s := stubCluster(t)
+
+	if err := r.ClusterSaveConfig(context.Background()); err != nil {
+		t.Fatalf("ClusterSaveConfig: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "saveconfig")
+}
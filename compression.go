@@ -0,0 +1,79 @@
+package redisCache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+const (
+	// headerMagic starts every value that records how it was encoded, followed by one of
+	// the kinds below. The trailing "1" versions the format. Values without the header,
+	// such as those written before compression was introduced, are read back unchanged.
+	headerMagic = "\x00rc1"
+	// kindPlain marks an uncompressed value that would otherwise start with headerMagic.
+	kindPlain byte = 'p'
+	// kindGzip marks a gzip-compressed value.
+	kindGzip byte = 'z'
+	// kindNull marks the placeholder RememberMiss stores for data that does not exist.
+	kindNull byte = 'n'
+	// embstrLimit is the largest string Redis stores with the embstr encoding.
+	embstrLimit = 44
+)
+
+// encodeValue Compress string values longer than Config.CompressionThreshold.
+// Values short enough for the embstr encoding are never compressed, since the gzip
+// overhead outweighs any saving. Other values are stored unchanged.
+func (r *Redis) encodeValue(value interface{}) interface{} {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return value
+	}
+
+	threshold := r.config.CompressionThreshold
+	if threshold >= 0 && len(data) > embstrLimit && len(data) > threshold {
+		var buf bytes.Buffer
+		buf.WriteString(headerMagic)
+		buf.WriteByte(kindGzip)
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err == nil && zw.Close() == nil && buf.Len() < len(data) {
+			return buf.Bytes()
+		}
+	}
+	if bytes.HasPrefix(data, []byte(headerMagic)) {
+		return append([]byte(headerMagic+string(kindPlain)), data...)
+	}
+
+	return value
+}
+
+// decodeValue Strip the header written by encodeValue, decompressing if needed.
+func decodeValue(val string) string {
+	if len(val) <= len(headerMagic) || !strings.HasPrefix(val, headerMagic) {
+		return val
+	}
+	body := val[len(headerMagic)+1:]
+	switch val[len(headerMagic)] {
+	case kindPlain:
+		return body
+	case kindGzip:
+		zr, err := gzip.NewReader(strings.NewReader(body))
+		if err != nil {
+			return val
+		}
+		defer zr.Close()
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return val
+		}
+		return string(data)
+	}
+
+	return val
+}
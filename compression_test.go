@@ -0,0 +1,80 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	r, m := newTestStore(t, Config{CompressionThreshold: 100})
+	large := strings.Repeat("redis ", 100)
+
+	for _, value := range []string{"short", large, headerMagic + "p-looking value"} {
+		_ = r.Put("k", value, 0)
+		if got := r.GetString("k", ""); got != value {
+			t.Fatalf("round trip of %q = %q", value, got)
+		}
+	}
+
+	_ = r.Put("large", large, 0)
+	if raw, _ := m.Get("large"); len(raw) >= len(large) || !strings.HasPrefix(raw, headerMagic) {
+		t.Fatalf("large value stored uncompressed (%d bytes)", len(raw))
+	}
+}
+
+func TestDecodeLegacyValues(t *testing.T) {
+	r, m := newTestStore(t)
+	for _, legacy := range []string{"\x00binary", "\x01binary", "\x00rc"} {
+		m.Set("legacy", legacy)
+		if got := r.GetString("legacy", ""); got != legacy {
+			t.Fatalf("legacy value %q read back as %q", legacy, got)
+		}
+	}
+}
+
+func TestCompressionAppliesToAtomicWrites(t *testing.T) {
+	r, m := newTestStore(t, Config{CompressionThreshold: 100})
+	ctx := context.Background()
+	large := strings.Repeat("a", 500)
+	larger := strings.Repeat("b", 500)
+
+	_ = r.Put("k", large, 0)
+	if ok, err := r.CompareAndSwap(ctx, "k", large, larger, 0); err != nil || !ok {
+		t.Fatalf("CompareAndSwap on a compressed value = %v, %v", ok, err)
+	}
+	err := r.AtomicUpdate(ctx, "k", 0, func(current string) (string, error) {
+		if current != larger {
+			t.Fatalf("AtomicUpdate saw %d bytes, want the decoded value", len(current))
+		}
+		return current + "!", nil
+	})
+	if err != nil {
+		t.Fatalf("AtomicUpdate: %v", err)
+	}
+	if got := r.GetString("k", ""); got != larger+"!" {
+		t.Fatal("AtomicUpdate result was not decoded")
+	}
+
+	_ = CacheGroup(r, "g", time.Minute).Put(ctx, "member", large)
+	if raw, _ := m.Get("member"); len(raw) >= len(large) {
+		t.Fatal("Group.Put stored the value uncompressed")
+	}
+	if got := r.GetString("member", ""); got != large {
+		t.Fatal("Group.Put value was not decoded")
+	}
+}
+
+func TestNullMarkerReadsAsMissing(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+	_, _ = r.RememberMiss(ctx, "k", time.Hour, time.Minute, func() (interface{}, error) { return nil, ErrNotFound })
+
+	if got := r.Get("k", "def"); got != "def" {
+		t.Fatalf("Get of a remembered miss = %q, want def", got)
+	}
+	if got := r.GetMany([]string{"k"})["k"]; got != nil {
+		t.Fatalf("GetMany of a remembered miss = %q, want nil", got)
+	}
+}
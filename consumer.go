@@ -0,0 +1,156 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// AtLeastOnceConsumer Consume stream as consumer of group until ctx is done, calling handler for
+// every message and acknowledging it once handler succeeds. Handler errors are reported to
+// Config.OnConsumerError and the message stays pending; once it has been pending for
+// Config.RedeliveryDelay it is claimed and delivered again, also when it was left behind by
+// another consumer of the group. After Config.MaxDeliveryAttempts deliveries a message is moved
+// to the dead-letter stream stream+".dlq" and acknowledged. The group is created when missing.
+func (r *Redis) AtLeastOnceConsumer(ctx context.Context, stream, group, consumer string, handler func(msg redis.XMessage) error) error {
+	key := r.Prefix + stream
+	err := r.Redis.XGroupCreateMkStream(ctx, key, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	// New messages are polled at least as often as pending ones become due again.
+	block := time.Second
+	if r.config.RedeliveryDelay < block {
+		block = r.config.RedeliveryDelay
+	}
+	if block < time.Millisecond {
+		block = time.Millisecond
+	}
+	for ctx.Err() == nil {
+		msgs, err := r.claimIdle(ctx, key, group, consumer)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			if msgs, err = r.readGroup(ctx, key, group, consumer, ">", block); err != nil {
+				return err
+			}
+		}
+		for _, msg := range msgs {
+			if err := r.deliver(ctx, stream, key, group, consumer, msg, handler); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// claimIdle Claim for consumer up to ten messages of group that have been pending for at least
+// Config.RedeliveryDelay. Only the hundred oldest pending messages are considered per call.
+func (r *Redis) claimIdle(ctx context.Context, key, group, consumer string) ([]redis.XMessage, error) {
+	pending, err := r.Redis.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: key,
+		Group:  group,
+		Start:  "-",
+		End:    "+",
+		Count:  100,
+	}).Result()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	var ids []string
+	for _, p := range pending {
+		if p.Idle >= r.config.RedeliveryDelay {
+			ids = append(ids, p.ID)
+			if len(ids) == 10 {
+				break
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	msgs, err := r.Redis.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  r.config.RedeliveryDelay,
+		Messages: ids,
+	}).Result()
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return msgs, err
+}
+
+// readGroup Read up to ten messages for consumer starting after id, blocking for block when
+// block is not negative. A timeout yields no messages.
+func (r *Redis) readGroup(ctx context.Context, key, group, consumer, id string, block time.Duration) ([]redis.XMessage, error) {
+	res, err := r.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{key, id},
+		Count:    10,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0].Messages, nil
+}
+
+// deliver Hand msg to handler, or to the dead-letter stream once it has been delivered too often.
+func (r *Redis) deliver(ctx context.Context, stream, key, group, consumer string, msg redis.XMessage, handler func(msg redis.XMessage) error) error {
+	if len(msg.Values) == 0 {
+		// The entry was trimmed from the stream while pending; only its PEL slot remains.
+		return r.Redis.XAck(ctx, key, group, msg.ID).Err()
+	}
+	pending, err := r.Redis.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   key,
+		Group:    group,
+		Start:    msg.ID,
+		End:      msg.ID,
+		Count:    1,
+		Consumer: consumer,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 && pending[0].RetryCount > r.config.MaxDeliveryAttempts {
+		_, err := r.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.XAdd(ctx, &redis.XAddArgs{Stream: key + ".dlq", Values: msg.Values})
+			pipe.XAck(ctx, key, group, msg.ID)
+			return nil
+		})
+		return err
+	}
+
+	if err := handler(msg); err != nil {
+		if r.config.OnConsumerError != nil {
+			r.config.OnConsumerError(stream, msg, err)
+		}
+		return nil
+	}
+
+	return r.Redis.XAck(ctx, key, group, msg.ID).Err()
+}
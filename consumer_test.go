@@ -0,0 +1,131 @@
+package redisCache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestAtLeastOnceConsumerRetries(t *testing.T) {
+	var mu sync.Mutex
+	var failures []error
+	r, _ := newTestStore(t, Config{
+		Prefix:          "app:",
+		RedeliveryDelay: 50 * time.Millisecond,
+		OnConsumerError: func(stream string, msg redis.XMessage, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if stream != "jobs" {
+				t.Errorf("OnConsumerError stream = %q, want jobs", stream)
+			}
+			failures = append(failures, err)
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts []time.Time
+	done := make(chan struct{})
+	handler := func(msg redis.XMessage) error {
+		attempts = append(attempts, time.Now())
+		if len(attempts) <= 2 {
+			return errors.New("temporary failure")
+		}
+		close(done)
+		return nil
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- r.AtLeastOnceConsumer(ctx, "jobs", "workers", "w1", handler) }()
+
+	if _, err := r.StreamAppend(ctx, "jobs", 0, map[string]interface{}{"id": "1"}); err != nil {
+		t.Fatalf("StreamAppend: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("message was not handled successfully")
+	}
+	bg := context.Background()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(5 * time.Millisecond) {
+		pending, err := r.Redis.XPending(bg, "app:jobs", "workers").Result()
+		if err == nil && pending.Count == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("XPending = %v, %v, want the message acknowledged", pending, err)
+		}
+	}
+	cancel()
+	if err := <-stopped; err != context.Canceled {
+		t.Fatalf("AtLeastOnceConsumer = %v, want context.Canceled", err)
+	}
+
+	for i := 1; i < len(attempts); i++ {
+		if gap := attempts[i].Sub(attempts[i-1]); gap < 50*time.Millisecond {
+			t.Fatalf("attempt %d came %v after the previous one, want at least the redelivery delay", i+1, gap)
+		}
+	}
+	if len(failures) != 2 {
+		t.Fatalf("OnConsumerError called %d times, want 2", len(failures))
+	}
+	if n, _ := r.Redis.XLen(bg, "app:jobs.dlq").Result(); n != 0 {
+		t.Fatalf("dead-letter stream holds %d messages, want 0", n)
+	}
+}
+
+func TestAtLeastOnceConsumerDeadLetters(t *testing.T) {
+	r, _ := newTestStore(t, Config{
+		Prefix:              "app:",
+		MaxDeliveryAttempts: 2,
+		RedeliveryDelay:     20 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	attempts := 0
+	handler := func(msg redis.XMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return errors.New("permanent failure")
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- r.AtLeastOnceConsumer(ctx, "jobs", "workers", "w1", handler) }()
+
+	if _, err := r.StreamAppend(ctx, "jobs", 0, map[string]interface{}{"id": "1", "kind": "email"}); err != nil {
+		t.Fatalf("StreamAppend: %v", err)
+	}
+	bg := context.Background()
+	var dead []redis.XMessage
+	for deadline := time.Now().Add(3 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		var err error
+		dead, err = r.Redis.XRange(bg, "app:jobs.dlq", "-", "+").Result()
+		if err == nil && len(dead) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("message was not moved to the dead-letter stream")
+		}
+	}
+	cancel()
+	if err := <-stopped; err != context.Canceled {
+		t.Fatalf("AtLeastOnceConsumer = %v, want context.Canceled", err)
+	}
+
+	if len(dead) != 1 || dead[0].Values["id"] != "1" || dead[0].Values["kind"] != "email" {
+		t.Fatalf("dead-letter stream = %v, want the message values", dead)
+	}
+	if pending, err := r.Redis.XPending(bg, "app:jobs", "workers").Result(); err != nil || pending.Count != 0 {
+		t.Fatalf("XPending = %v, %v, want the message acknowledged", pending, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Fatalf("handler called %d times, want MaxDeliveryAttempts", attempts)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build redis_debug
+
+package redisCache
+
+import (
+	"context"
+)
+
+// DebugReload Save the dataset to disk, flush it and reload it from the RDB file.
+func (r *Redis) DebugReload(ctx context.Context) error {
+	return r.Redis.Do(ctx, "debug", "reload").Err()
+}
+
+// DebugJmap Dump the server heap to the Redis log.
+// DEBUG JMAP is not available on production builds of Redis; it only works against a
+// server compiled with debugging support.
+func (r *Redis) DebugJmap(ctx context.Context) error {
+	return r.Redis.Do(ctx, "debug", "jmap").Err()
+}
+
+// DebugQuicklistPackedThreshold Set the size above which quicklist nodes store elements
+// as plain nodes instead of packed ones, allowing tests to force a given encoding.
+func (r *Redis) DebugQuicklistPackedThreshold(ctx context.Context, size int64) error {
+	return r.Redis.Do(ctx, "debug", "quicklist-packed-threshold", size).Err()
+}
+
+// ClusterReset Reset the cluster state of the connected node. A soft reset forgets the
+// other nodes and slot assignments; a hard reset also generates a new node ID and zeroes
+// the epochs.
+func (r *Redis) ClusterReset(ctx context.Context, soft bool) error {
+	if soft {
+		return r.Redis.ClusterResetSoft(ctx).Err()
+	}
+
+	return r.Redis.ClusterResetHard(ctx).Err()
+}
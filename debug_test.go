@@ -0,0 +1,57 @@
+//go:build redis_debug
+
+package redisCache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+func TestDebugReload(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("DEBUG", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.DebugReload(context.Background()); err != nil {
+		t.Fatalf("DebugReload: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "DEBUG"), "reload")
+}
+
+func TestDebugJmap(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("DEBUG", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.DebugJmap(context.Background()); err != nil {
+		t.Fatalf("DebugJmap: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "DEBUG"), "jmap")
+}
+
+func TestDebugQuicklistPackedThreshold(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("DEBUG", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.DebugQuicklistPackedThreshold(context.Background(), 1024); err != nil {
+		t.Fatalf("DebugQuicklistPackedThreshold: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "DEBUG"), "quicklist-packed-threshold", "1024")
+}
+
+func TestClusterReset(t *testing.T) {
+	r, s := stubCluster(t)
+	ctx := context.Background()
+
+	if err := r.ClusterReset(ctx, true); err != nil {
+		t.Fatalf("ClusterReset soft: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "reset", "soft")
+	if err := r.ClusterReset(ctx, false); err != nil {
+		t.Fatalf("ClusterReset hard: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CLUSTER"), "reset", "hard")
+}
@@ -0,0 +1,34 @@
+package redisCache
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNoConfigFile is returned by ConfigRewrite when the server was started without a config file.
+	ErrNoConfigFile = errors.New("redisCache: server is running without a config file")
+	// ErrSaveInProgress is returned by BGSave when a background save is already running.
+	ErrSaveInProgress = errors.New("redisCache: background save already in progress")
+	// ErrAOFDisabled is returned by BGRewriteAOF when append-only persistence is not enabled.
+	ErrAOFDisabled = errors.New("redisCache: append-only file is not enabled")
+	// ErrNotMaster is returned by commands that must be sent to a master but reached a replica.
+	ErrNotMaster = errors.New("redisCache: command must be sent to a master")
+	// ErrInvalidAddress is returned when a host or port argument is empty or out of range.
+	ErrInvalidAddress = errors.New("redisCache: invalid host or port")
+	// ErrUnsupportedStore is returned when a helper needs a Redis-backed cache.Store.
+	ErrUnsupportedStore = errors.New("redisCache: store is not backed by Redis")
+	// ErrInvalidVersion is returned by FlushVersion for versions that do not namespace keys.
+	ErrInvalidVersion = errors.New("redisCache: version must be positive")
+	// ErrNotCopied is returned when COPY finds no source key or an existing destination.
+	ErrNotCopied = errors.New("redisCache: key was not copied")
+	// ErrNotFound is returned by RememberMiss callbacks to signal that the data does not exist.
+	ErrNotFound = errors.New("redisCache: not found")
+	// ErrVersionNotSupported is returned by New when the server is older than Config.MinVersion.
+	ErrVersionNotSupported = errors.New("redisCache: redis version not supported")
+	// ErrForgetSelf is returned by ClusterForget when asked to forget the connected node.
+	ErrForgetSelf = errors.New("redisCache: a node cannot forget itself")
+	// ErrInvalidSlotState is returned by ClusterSetSlot for unknown slot states.
+	ErrInvalidSlotState = errors.New("redisCache: slot state must be MIGRATING, IMPORTING, STABLE or NODE")
+	// ErrUnsupportedProtocol is returned by Hello for protocol versions the client cannot decode.
+	ErrUnsupportedProtocol = errors.New("redisCache: only RESP2 is supported")
+)
@@ -0,0 +1,93 @@
+package redisCache
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Subscription is a running Pub/Sub listener started by the store.
+// It stops when closed or when the context it was started with is done.
+type Subscription struct {
+	pubsub *redis.PubSub
+	cancel context.CancelFunc
+	once   sync.Once
+	err    error
+}
+
+// Close Stop the listener and release its connection.
+func (s *Subscription) Close() error {
+	s.cancel()
+	return s.close()
+}
+
+func (s *Subscription) close() error {
+	s.once.Do(func() {
+		s.err = s.pubsub.Close()
+	})
+
+	return s.err
+}
+
+// OnExpiry Call fn with the key, prefix stripped, of every expired key matching keyPattern.
+// keyPattern uses path.Match syntax. Keyspace notifications for expired events are enabled
+// on the server when they are not already.
+func (r *Redis) OnExpiry(ctx context.Context, keyPattern string, fn func(expiredKey string)) (*Subscription, error) {
+	if err := r.enableExpiredEvents(ctx); err != nil {
+		return nil, err
+	}
+
+	channel := "__keyevent@" + strconv.Itoa(r.config.DB) + "__:expired"
+	pubsub := r.Redis.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{pubsub: pubsub, cancel: cancel}
+	go func() {
+		defer sub.close()
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !strings.HasPrefix(msg.Payload, r.Prefix) {
+					continue
+				}
+				key := strings.TrimPrefix(msg.Payload, r.Prefix)
+				if matched, _ := path.Match(keyPattern, key); matched {
+					fn(key)
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// enableExpiredEvents Make sure notify-keyspace-events publishes keyevent notifications for expiries.
+func (r *Redis) enableExpiredEvents(ctx context.Context) error {
+	res, err := r.Redis.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		return err
+	}
+	var flags string
+	if len(res) == 2 {
+		flags, _ = res[1].(string)
+	}
+	if strings.Contains(flags, "E") && (strings.Contains(flags, "x") || strings.Contains(flags, "A")) {
+		return nil
+	}
+
+	return r.Redis.ConfigSet(ctx, "notify-keyspace-events", flags+"Ex").Err()
+}
@@ -0,0 +1,82 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// stubNotifications Answer CONFIG GET and SET for notify-keyspace-events, which miniredis lacks.
+func stubNotifications(s *stubs) {
+	s.on("CONFIG", func(c *server.Peer, args []string) {
+		if args[0] == "set" || args[0] == "SET" {
+			c.WriteOK()
+			return
+		}
+		c.WriteStrings([]string{"notify-keyspace-events", ""})
+	})
+}
+
+// expire Move the time of m forward by d and publish the keyevent notification Redis
+// would send for each of keys that expired.
+func expire(m *miniredis.Miniredis, d time.Duration, keys ...string) {
+	m.FastForward(d)
+	for _, key := range keys {
+		if !m.Exists(key) {
+			m.Publish("__keyevent@0__:expired", key)
+		}
+	}
+}
+
+func TestOnExpiry(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	stubNotifications(s)
+	expired := make(chan string, 4)
+
+	sub, err := r.OnExpiry(context.Background(), "session:*", func(key string) { expired <- key })
+	if err != nil {
+		t.Fatalf("OnExpiry: %v", err)
+	}
+	defer sub.Close()
+	equalArgs(t, s.lastCall(t, "CONFIG"), "set", "notify-keyspace-events", "Ex")
+
+	_ = r.Put("user:1", "v", 100*time.Millisecond)
+	_ = r.Put("session:1", "v", 100*time.Millisecond)
+	expire(m, 100*time.Millisecond, "app:user:1", "app:session:1")
+
+	select {
+	case key := <-expired:
+		if key != "session:1" {
+			t.Fatalf("expired %q, want session:1", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no expiry received")
+	}
+}
+
+func TestOnExpiryReleasesConnectionWhenContextIsDone(t *testing.T) {
+	r, m := newTestStore(t)
+	stubNotifications(stubCommands(m))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if _, err := r.OnExpiry(ctx, "*", func(string) {}); err != nil {
+		t.Fatalf("OnExpiry: %v", err)
+	}
+	channel := "__keyevent@0__:expired"
+	if n := m.PubSubNumSub(channel)[channel]; n != 1 {
+		t.Fatalf("%d subscribers, want 1", n)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for m.PubSubNumSub(channel)[channel] != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription kept its connection after ctx was cancelled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
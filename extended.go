@@ -0,0 +1,103 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// ExtendedStore is a cache.Store with the multi-key operations of the Laravel cache API.
+// Stores created by New implement it; type-assert a cache.Store to reach these methods.
+type ExtendedStore interface {
+	cache.Store
+	// GetMany Retrieve multiple items from the cache by key.
+	GetMany(keys []string) map[string]interface{}
+	// SetMany Store multiple items in the cache for a given number of seconds.
+	SetMany(values map[string]interface{}, seconds time.Duration) bool
+}
+
+var _ ExtendedStore = (*Redis)(nil)
+
+// GetMany Retrieve multiple items from the cache by key using a single MGET.
+// Missing keys are present in the result with a nil value.
+func (r *Redis) GetMany(keys []string) map[string]interface{} {
+	values := make(map[string]interface{}, len(keys))
+	res, err := r.Redis.MGet(r.ctx, r.prefixKeys(keys)...).Result()
+	for i, key := range keys {
+		values[key] = nil
+		if err == nil && i < len(res) {
+			values[key] = res[i]
+			if str, ok := res[i].(string); ok {
+				values[key] = decodeValue(str)
+				if str == nullMarker {
+					values[key] = nil
+				}
+			}
+		}
+	}
+
+	return values
+}
+
+// SetMany Store multiple items in the cache for a given number of seconds.
+// Items are written with MSET, followed by an EXPIRE per key when seconds is positive,
+// all in one transaction.
+func (r *Redis) SetMany(values map[string]interface{}, seconds time.Duration) bool {
+	if len(values) == 0 {
+		return true
+	}
+	pairs := make([]interface{}, 0, len(values)*2)
+	for key, value := range values {
+		pairs = append(pairs, r.Prefix+key, r.encodeValue(value))
+	}
+	_, err := r.Redis.TxPipelined(r.ctx, func(pipe redis.Pipeliner) error {
+		pipe.MSet(r.ctx, pairs...)
+		if seconds > 0 {
+			for key := range values {
+				pipe.Expire(r.ctx, r.Prefix+key, seconds)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false
+	}
+	for key := range values {
+		r.negative.Delete(key)
+	}
+
+	return true
+}
+
+// GetManyWithErrors Retrieve multiple items using one pipelined GET per key, so a failure on
+// one key, such as WRONGTYPE, does not fail the others. Missing keys are reported as redis.Nil.
+func (r *Redis) GetManyWithErrors(ctx context.Context, keys []string) (map[string]string, map[string]error) {
+	values := make(map[string]string, len(keys))
+	errs := make(map[string]error)
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, r.Prefix+key)
+		}
+		return nil
+	})
+	for i, key := range keys {
+		if cmds[i] == nil {
+			errs[key] = err
+			continue
+		}
+		val, cmdErr := cmds[i].Result()
+		if cmdErr == nil && val == nullMarker {
+			cmdErr = redis.Nil
+		}
+		if cmdErr != nil {
+			errs[key] = cmdErr
+			continue
+		}
+		values[key] = decodeValue(val)
+	}
+
+	return values, errs
+}
@@ -0,0 +1,75 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestSetMany(t *testing.T) {
+	r, s, _ := newNegativeStore(t)
+	if got := r.Get("a", "def"); got != "def" {
+		t.Fatalf("Get a = %v, want def", got)
+	}
+	if !r.isNegative("a") {
+		t.Fatal("miss was not remembered")
+	}
+
+	if !r.SetMany(map[string]interface{}{"a": "1", "b": 2}, time.Minute) {
+		t.Fatal("SetMany failed")
+	}
+	if r.isNegative("a") {
+		t.Fatal("SetMany kept the remembered miss")
+	}
+	if got := r.GetString("a", ""); got != "1" {
+		t.Fatalf("a = %q, want 1", got)
+	}
+	if got := r.GetInt("b", 0); got != 2 {
+		t.Fatalf("b = %d, want 2", got)
+	}
+	if n := len(s.called("EXPIRE")); n != 2 {
+		t.Fatalf("EXPIRE sent %d times, want one per key", n)
+	}
+	for _, key := range []string{"a", "b"} {
+		if ttl := r.Redis.TTL(r.ctx, key).Val(); ttl != time.Minute {
+			t.Fatalf("TTL of %s = %v, want 1m", key, ttl)
+		}
+	}
+
+	_ = r.SetMany(map[string]interface{}{"c": "3"}, 0)
+	if ttl := r.Redis.TTL(r.ctx, "c").Val(); ttl != -1 {
+		t.Fatalf("TTL of c = %v, want none", ttl)
+	}
+}
+
+func TestGetManyWithErrors(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:", CompressionThreshold: 64})
+	long := strings.Repeat("compressible ", 20)
+	if err := r.Put("big", long, time.Minute); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if raw, _ := m.Get("app:big"); raw == long {
+		t.Fatal("big was not compressed")
+	}
+	m.Set("app:plain", "v")
+	if _, err := m.Push("app:list", "x"); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	values, errs := r.GetManyWithErrors(context.Background(), []string{"big", "plain", "list", "missing"})
+	if len(values) != 2 || values["big"] != long || values["plain"] != "v" {
+		t.Fatalf("values = %v, want big and plain decoded", values)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errs = %v, want list and missing only", errs)
+	}
+	if err := errs["list"]; err == nil || !strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		t.Fatalf("errs[list] = %v, want WRONGTYPE", err)
+	}
+	if err := errs["missing"]; err != redis.Nil {
+		t.Fatalf("errs[missing] = %v, want redis.Nil", err)
+	}
+}
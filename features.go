@@ -0,0 +1,73 @@
+package redisCache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// FeatureSet lists the optional commands supported by the connected server.
+type FeatureSet struct {
+	Version    string
+	Hello      bool
+	BitFieldRO bool
+	GetDel     bool
+	GetEx      bool
+	Reset      bool
+	GeoSearch  bool
+	LMPop      bool
+	ZMPop      bool
+	SInterCard bool
+	ExpireTime bool
+}
+
+// getDelScript reads and deletes a key atomically on servers without GETDEL.
+var getDelScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+redis.call('DEL', KEYS[1])
+return value
+`)
+
+// SupportedFeatures Return the optional commands detected when the store was created.
+func (r *Redis) SupportedFeatures() FeatureSet {
+	return r.features
+}
+
+// detectFeatures Determine the server version, via HELLO when available and INFO otherwise.
+// When neither reports a version, for example because both are renamed or denied by ACLs,
+// no optional command is assumed to be available.
+func (r *Redis) detectFeatures(ctx context.Context) FeatureSet {
+	if info, err := r.Hello(ctx, 2); err == nil {
+		if version, ok := info["version"].(string); ok {
+			return featuresFor(version, true)
+		}
+	}
+	version, err := r.serverVersion(ctx)
+	if err != nil {
+		return FeatureSet{}
+	}
+
+	return featuresFor(version, false)
+}
+
+// featuresFor Derive the supported commands from a server version string.
+func featuresFor(version string, hello bool) FeatureSet {
+	at := func(major, minor int) bool {
+		ok, _ := versionAtLeast(version, major, minor)
+		return ok
+	}
+
+	return FeatureSet{
+		Version:    version,
+		Hello:      hello || at(6, 0),
+		BitFieldRO: at(6, 0),
+		GetDel:     at(6, 2),
+		GetEx:      at(6, 2),
+		Reset:      at(6, 2),
+		GeoSearch:  at(6, 2),
+		LMPop:      at(7, 0),
+		ZMPop:      at(7, 0),
+		SInterCard: at(7, 0),
+		ExpireTime: at(7, 0),
+	}
+}
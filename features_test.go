@@ -0,0 +1,45 @@
+package redisCache
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+func TestFeaturesFor(t *testing.T) {
+	f := featuresFor("6.2.14", false)
+	if !f.Hello || !f.BitFieldRO || !f.GetDel || !f.GetEx || !f.Reset || !f.GeoSearch {
+		t.Fatalf("featuresFor(6.2) = %+v, want every 6.2 command", f)
+	}
+	if f.LMPop || f.ZMPop || f.SInterCard || f.ExpireTime {
+		t.Fatalf("featuresFor(6.2) = %+v, want no 7.0 command", f)
+	}
+
+	f = featuresFor("7.0.0", true)
+	if !f.GetEx || !f.LMPop || !f.ZMPop || !f.SInterCard || !f.ExpireTime {
+		t.Fatalf("featuresFor(7.0) = %+v, want every 7.0 command", f)
+	}
+
+	if f := featuresFor("5.0.7", false); f != (FeatureSet{Version: "5.0.7"}) {
+		t.Fatalf("featuresFor(5.0) = %+v, want no optional command", f)
+	}
+}
+
+func TestNewWithoutVersion(t *testing.T) {
+	m := miniredis.RunT(t)
+	s := stubCommands(m)
+	denied := func(c *server.Peer, args []string) { c.WriteError("NOPERM this user has no permissions") }
+	s.on("HELLO", denied)
+	s.on("INFO", denied)
+
+	store, err := New(Config{Host: m.Host(), Port: m.Port()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r := store.(*Redis)
+	defer r.Redis.Close()
+	if f := r.SupportedFeatures(); f != (FeatureSet{}) {
+		t.Fatalf("SupportedFeatures = %+v, want no optional command", f)
+	}
+}
@@ -0,0 +1,151 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+)
+
+// FunctionListQuery filters the libraries returned by FunctionList.
+type FunctionListQuery struct {
+	LibraryNamePattern string
+	WithCode           bool
+}
+
+// Library is a Redis Functions library loaded on the server.
+type Library struct {
+	Name      string
+	Engine    string
+	Functions []Function
+	Code      string
+}
+
+// Function is a function registered by a Library.
+type Function struct {
+	Name        string
+	Description string
+	Flags       []string
+}
+
+// FunctionList Retrieve the libraries loaded on the server. Functions are global, so no
+// prefix is applied to the pattern. Requires Redis 7.0 or later.
+func (r *Redis) FunctionList(ctx context.Context, query *FunctionListQuery) ([]Library, error) {
+	args := []interface{}{"function", "list"}
+	if query != nil {
+		if query.LibraryNamePattern != "" {
+			args = append(args, "libraryname", query.LibraryNamePattern)
+		}
+		if query.WithCode {
+			args = append(args, "withcode")
+		}
+	}
+	res, err := r.Redis.Do(ctx, args...).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	libs := make([]Library, 0, len(res))
+	for _, item := range res {
+		fields := flatPairs(item)
+		lib := Library{}
+		lib.Name, _ = fields["library_name"].(string)
+		lib.Engine, _ = fields["engine"].(string)
+		lib.Code, _ = fields["library_code"].(string)
+		functions, _ := fields["functions"].([]interface{})
+		for _, f := range functions {
+			lib.Functions = append(lib.Functions, parseFunction(f))
+		}
+		libs = append(libs, lib)
+	}
+
+	return libs, nil
+}
+
+func parseFunction(v interface{}) Function {
+	var fn Function
+	fields := flatPairs(v)
+	fn.Name, _ = fields["name"].(string)
+	fn.Description, _ = fields["description"].(string)
+	flags, _ := fields["flags"].([]interface{})
+	for _, flag := range flags {
+		if s, ok := flag.(string); ok {
+			fn.Flags = append(fn.Flags, s)
+		}
+	}
+
+	return fn
+}
+
+// FunctionDelete Remove a library and all of its functions from the server.
+func (r *Redis) FunctionDelete(ctx context.Context, libraryName string) error {
+	return r.Redis.Do(ctx, "function", "delete", libraryName).Err()
+}
+
+// FunctionDump Retrieve a serialized payload of every loaded library, for use with FunctionRestore.
+func (r *Redis) FunctionDump(ctx context.Context) (string, error) {
+	return r.Redis.Do(ctx, "function", "dump").Text()
+}
+
+// FunctionRestore Load the libraries of a payload produced by FunctionDump.
+// Libraries that already exist make the restore fail.
+func (r *Redis) FunctionRestore(ctx context.Context, payload string) error {
+	return r.Redis.Do(ctx, "function", "restore", payload).Err()
+}
+
+// FunctionStats describes the function currently running on the server and the loaded engines.
+type FunctionStats struct {
+	// Running is nil when no function is executing.
+	Running *RunningFunction
+	Engines []FunctionEngine
+}
+
+// RunningFunction is a function executing on the server.
+type RunningFunction struct {
+	Name     string
+	Command  []string
+	Duration time.Duration
+}
+
+// FunctionEngine reports the libraries and functions loaded for an engine.
+type FunctionEngine struct {
+	Name           string
+	LibrariesCount int64
+	FunctionsCount int64
+}
+
+// FunctionStats Retrieve the running function, if any, and per-engine statistics.
+// Useful for spotting runaway scripts. Requires Redis 7.0 or later.
+func (r *Redis) FunctionStats(ctx context.Context) (*FunctionStats, error) {
+	res, err := r.Redis.Do(ctx, "function", "stats").Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &FunctionStats{}
+	fields := flatPairs(res)
+	if running, ok := fields["running_script"].([]interface{}); ok {
+		info := flatPairs(running)
+		fn := &RunningFunction{}
+		fn.Name, _ = info["name"].(string)
+		command, _ := info["command"].([]interface{})
+		for _, arg := range command {
+			if s, ok := arg.(string); ok {
+				fn.Command = append(fn.Command, s)
+			}
+		}
+		if ms, ok := info["duration_ms"].(int64); ok {
+			fn.Duration = time.Duration(ms) * time.Millisecond
+		}
+		stats.Running = fn
+	}
+	engines, _ := fields["engines"].([]interface{})
+	for i := 0; i+1 < len(engines); i += 2 {
+		name, _ := engines[i].(string)
+		info := flatPairs(engines[i+1])
+		engine := FunctionEngine{Name: name}
+		engine.LibrariesCount, _ = info["libraries_count"].(int64)
+		engine.FunctionsCount, _ = info["functions_count"].(int64)
+		stats.Engines = append(stats.Engines, engine)
+	}
+
+	return stats, nil
+}
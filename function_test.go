@@ -0,0 +1,128 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+func TestFunctionList(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("FUNCTION", func(c *server.Peer, args []string) {
+		c.WriteLen(1)
+		c.WriteLen(8)
+		c.WriteBulk("library_name")
+		c.WriteBulk("mylib")
+		c.WriteBulk("engine")
+		c.WriteBulk("LUA")
+		c.WriteBulk("functions")
+		c.WriteLen(1)
+		c.WriteLen(6)
+		c.WriteBulk("name")
+		c.WriteBulk("myfunc")
+		c.WriteBulk("description")
+		c.WriteNull()
+		c.WriteBulk("flags")
+		c.WriteStrings([]string{"no-writes"})
+		c.WriteBulk("library_code")
+		c.WriteBulk("#!lua name=mylib")
+	})
+
+	libs, err := r.FunctionList(context.Background(), &FunctionListQuery{LibraryNamePattern: "my*", WithCode: true})
+	if err != nil {
+		t.Fatalf("FunctionList: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "FUNCTION"), "list", "libraryname", "my*", "withcode")
+	if len(libs) != 1 || libs[0].Name != "mylib" || libs[0].Engine != "LUA" || libs[0].Code != "#!lua name=mylib" {
+		t.Fatalf("FunctionList = %+v", libs)
+	}
+	fns := libs[0].Functions
+	if len(fns) != 1 || fns[0].Name != "myfunc" || fns[0].Description != "" || len(fns[0].Flags) != 1 || fns[0].Flags[0] != "no-writes" {
+		t.Fatalf("Functions = %+v", fns)
+	}
+}
+
+func TestFunctionDelete(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("FUNCTION", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.FunctionDelete(context.Background(), "mylib"); err != nil {
+		t.Fatalf("FunctionDelete: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "FUNCTION"), "delete", "mylib")
+}
+
+func TestFunctionDumpRestore(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	const payload = "\xf5\xc3@X@]\x1f#!lua name=mylib\n"
+	s.on("FUNCTION", func(c *server.Peer, args []string) {
+		if args[0] == "dump" {
+			c.WriteBulk(payload)
+			return
+		}
+		c.WriteOK()
+	})
+	ctx := context.Background()
+
+	dump, err := r.FunctionDump(ctx)
+	if err != nil || dump != payload {
+		t.Fatalf("FunctionDump = %q, %v", dump, err)
+	}
+	if err := r.FunctionRestore(ctx, dump); err != nil {
+		t.Fatalf("FunctionRestore: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "FUNCTION"), "restore", payload)
+}
+
+func TestFunctionStats(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	running := true
+	s.on("FUNCTION", func(c *server.Peer, args []string) {
+		c.WriteLen(4)
+		c.WriteBulk("running_script")
+		if running {
+			c.WriteLen(6)
+			c.WriteBulk("name")
+			c.WriteBulk("myfunc")
+			c.WriteBulk("command")
+			c.WriteStrings([]string{"fcall", "myfunc", "0"})
+			c.WriteBulk("duration_ms")
+			c.WriteInt(1500)
+		} else {
+			c.WriteNull()
+		}
+		c.WriteBulk("engines")
+		c.WriteLen(2)
+		c.WriteBulk("LUA")
+		c.WriteLen(4)
+		c.WriteBulk("libraries_count")
+		c.WriteInt(1)
+		c.WriteBulk("functions_count")
+		c.WriteInt(2)
+	})
+	ctx := context.Background()
+
+	stats, err := r.FunctionStats(ctx)
+	if err != nil {
+		t.Fatalf("FunctionStats: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "FUNCTION"), "stats")
+	fn := stats.Running
+	if fn == nil || fn.Name != "myfunc" || len(fn.Command) != 3 || fn.Duration != 1500*time.Millisecond {
+		t.Fatalf("Running = %+v", fn)
+	}
+	if len(stats.Engines) != 1 || stats.Engines[0] != (FunctionEngine{Name: "LUA", LibrariesCount: 1, FunctionsCount: 2}) {
+		t.Fatalf("Engines = %+v", stats.Engines)
+	}
+
+	running = false
+	if stats, err := r.FunctionStats(ctx); err != nil || stats.Running != nil {
+		t.Fatalf("FunctionStats when idle = %+v, %v, want no running function", stats, err)
+	}
+}
@@ -0,0 +1,61 @@
+package redisCache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GeoSearch Query the members of a geospatial index within a radius or box.
+// It replaces the GEORADIUS family, deprecated since Redis 6.2.
+func (r *Redis) GeoSearch(ctx context.Context, key string, q *redis.GeoSearchQuery) ([]string, error) {
+	return r.Redis.GeoSearch(ctx, r.Prefix+key, q).Result()
+}
+
+// GeoSearchStore Query a geospatial index and store the matching members in dest.
+func (r *Redis) GeoSearchStore(ctx context.Context, src, dest string, q *redis.GeoSearchStoreQuery) (int64, error) {
+	return r.Redis.GeoSearchStore(ctx, r.Prefix+src, r.Prefix+dest, q).Result()
+}
+
+// GeoHash Retrieve the Geohash string of each member of a geospatial index.
+func (r *Redis) GeoHash(ctx context.Context, key string, members ...string) ([]string, error) {
+	return r.Redis.GeoHash(ctx, r.Prefix+key, members...).Result()
+}
+
+// GeoRadiusStore Store the members of a geospatial index within a radius of a point in the
+// sorted set named by query.Store, or by query.StoreDist to store distances instead of hashes.
+// GEORADIUS is deprecated since Redis 6.2; on such servers the query is sent as GEOSEARCHSTORE
+// unless both Store and StoreDist are set. Prefer GeoSearchStore in new code.
+// A nil query is treated as empty and fails for lack of a destination.
+func (r *Redis) GeoRadiusStore(ctx context.Context, key string, longitude, latitude float64, query *redis.GeoRadiusQuery) (int64, error) {
+	var q redis.GeoRadiusQuery
+	if query != nil {
+		q = *query
+	}
+	if q.Store != "" {
+		q.Store = r.Prefix + q.Store
+	}
+	if q.StoreDist != "" {
+		q.StoreDist = r.Prefix + q.StoreDist
+	}
+
+	if r.features.GeoSearch && (q.Store == "") != (q.StoreDist == "") {
+		dest := q.Store
+		if q.StoreDist != "" {
+			dest = q.StoreDist
+		}
+		return r.Redis.GeoSearchStore(ctx, r.Prefix+key, dest, &redis.GeoSearchStoreQuery{
+			GeoSearchQuery: redis.GeoSearchQuery{
+				Longitude:  longitude,
+				Latitude:   latitude,
+				Radius:     q.Radius,
+				RadiusUnit: q.Unit,
+				Sort:       q.Sort,
+				Count:      q.Count,
+			},
+			StoreDist: q.StoreDist != "",
+		}).Result()
+	}
+
+	return r.Redis.GeoRadiusStore(ctx, r.Prefix+key, longitude, latitude, &q).Result()
+}
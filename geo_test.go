@@ -0,0 +1,159 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/geohash"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestGeoSearch(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+
+	r.Redis.GeoAdd(ctx, "app:cities",
+		&redis.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		&redis.GeoLocation{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+	)
+	members, err := r.GeoSearch(ctx, "cities", &redis.GeoSearchQuery{
+		Longitude:  15,
+		Latitude:   37,
+		Radius:     100,
+		RadiusUnit: "km",
+		Sort:       "ASC",
+	})
+	if err != nil {
+		t.Fatalf("GeoSearch: %v", err)
+	}
+	if len(members) != 1 || members[0] != "Catania" {
+		t.Fatalf("GeoSearch = %v, want [Catania]", members)
+	}
+}
+
+// stubGeoHash Answer GEOHASH, which miniredis lacks, with the standard 11-character Geohash of
+// the position miniredis stored for each member.
+func stubGeoHash(m *miniredis.Miniredis, s *stubs) {
+	s.on("GEOHASH", func(c *server.Peer, args []string) {
+		members, _ := m.ZMembers(args[0])
+		stored := map[string]bool{}
+		for _, member := range members {
+			stored[member] = true
+		}
+		c.WriteLen(len(args) - 1)
+		for _, member := range args[1:] {
+			if !stored[member] {
+				c.WriteNull()
+				continue
+			}
+			score, _ := m.ZScore(args[0], member)
+			lat, lng := geohash.DecodeIntWithPrecision(uint64(score), 52)
+			c.WriteBulk(standardGeohash(lat, lng))
+		}
+	})
+}
+
+// standardGeohash Encode a position as an 11-character Geohash over the full latitude range,
+// as GEOHASH does.
+func standardGeohash(lat, lng float64) string {
+	const alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	hash := make([]byte, 11)
+	for i := 0; i < 55; i++ {
+		rng, v := &lngRange, lng
+		if i%2 == 1 {
+			rng, v = &latRange, lat
+		}
+		mid := (rng[0] + rng[1]) / 2
+		bit := byte(0)
+		if v >= mid {
+			bit = 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		hash[i/5] = hash[i/5]<<1 | bit
+	}
+	for i, c := range hash {
+		hash[i] = alphabet[c]
+	}
+
+	return string(hash)
+}
+
+func TestGeoHash(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	stubGeoHash(m, stubCommands(m))
+	ctx := context.Background()
+	r.Redis.GeoAdd(ctx, "app:cities", &redis.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556})
+
+	hashes, err := r.GeoHash(ctx, "cities", "Palermo", "Atlantis")
+	if err != nil {
+		t.Fatalf("GeoHash: %v", err)
+	}
+	if len(hashes) != 2 || len(hashes[0]) != 11 || hashes[0][:10] != "sqc8b49rny" {
+		t.Fatalf("GeoHash = %q, want the 11-character hash of Palermo", hashes)
+	}
+	if hashes[1] != "" {
+		t.Fatalf("GeoHash of a missing member = %q, want empty", hashes[1])
+	}
+}
+
+func TestGeoRadiusStore(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	r.features.GeoSearch = false
+	r.Redis.GeoAdd(ctx, "app:cities",
+		&redis.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		&redis.GeoLocation{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+	)
+	s := stubCommands(m)
+
+	n, err := r.GeoRadiusStore(ctx, "cities", 15, 37, &redis.GeoRadiusQuery{Radius: 200, Unit: "km", Store: "near"})
+	if err != nil || n != 2 {
+		t.Fatalf("GeoRadiusStore = %d, %v, want 2, nil", n, err)
+	}
+	if members, _ := m.ZMembers("app:near"); len(members) != 2 {
+		t.Fatalf("app:near = %v, want both cities", members)
+	}
+
+	n, err = r.GeoRadiusStore(ctx, "cities", 15, 37, &redis.GeoRadiusQuery{Radius: 100, Unit: "km", StoreDist: "dist"})
+	if err != nil || n != 1 {
+		t.Fatalf("GeoRadiusStore = %d, %v, want 1, nil", n, err)
+	}
+	if dist, _ := m.ZScore("app:dist", "Catania"); dist <= 0 || dist >= 100 {
+		t.Fatalf("app:dist Catania = %v, want its distance in km", dist)
+	}
+	if calls := s.called("GEOSEARCHSTORE"); len(calls) != 0 {
+		t.Fatalf("GEOSEARCHSTORE sent without features.GeoSearch: %v", calls)
+	}
+
+	r.features.GeoSearch = true
+	s.on("GEOSEARCHSTORE", func(c *server.Peer, args []string) { c.WriteInt(1) })
+	georadius := len(s.called("GEORADIUS"))
+
+	if _, err := r.GeoRadiusStore(ctx, "cities", 15, 37, &redis.GeoRadiusQuery{Radius: 100, Unit: "km", Store: "near"}); err != nil {
+		t.Fatalf("GeoRadiusStore: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "GEOSEARCHSTORE")[:3], "app:near", "app:cities", "fromlonlat")
+
+	if _, err := r.GeoRadiusStore(ctx, "cities", 15, 37, &redis.GeoRadiusQuery{Radius: 100, Unit: "km", StoreDist: "dist"}); err != nil {
+		t.Fatalf("GeoRadiusStore: %v", err)
+	}
+	args := s.lastCall(t, "GEOSEARCHSTORE")
+	equalArgs(t, args[:2], "app:dist", "app:cities")
+	if strings.ToLower(args[len(args)-1]) != "storedist" {
+		t.Fatalf("GEOSEARCHSTORE args = %v, want STOREDIST", args)
+	}
+	if got := len(s.called("GEORADIUS")); got != georadius {
+		t.Fatalf("GEORADIUS sent with features.GeoSearch")
+	}
+
+	if _, err := r.GeoRadiusStore(ctx, "cities", 15, 37, nil); err == nil {
+		t.Fatal("GeoRadiusStore with a nil query succeeded")
+	}
+}
@@ -0,0 +1,88 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// Group tracks a set of cache keys that share a TTL and are invalidated together.
+type Group struct {
+	store    *Redis
+	groupKey string
+	ttl      time.Duration
+}
+
+// CacheGroup Create a Group whose members are tracked in a Redis set stored at groupKey.
+// The store must be created by New; other stores make every Group method return ErrUnsupportedStore.
+func CacheGroup(store cache.Store, groupKey string, ttl time.Duration) *Group {
+	r, _ := store.(*Redis)
+	return &Group{
+		store:    r,
+		groupKey: groupKey,
+		ttl:      ttl,
+	}
+}
+
+// Put Store an item for the group TTL and add it to the group.
+func (g *Group) Put(ctx context.Context, key string, value interface{}) error {
+	if g.store == nil {
+		return ErrUnsupportedStore
+	}
+	r := g.store
+	_, err := r.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.Prefix+key, r.encodeValue(value), g.ttl)
+		pipe.SAdd(ctx, r.Prefix+g.groupKey, key)
+		if g.ttl > 0 {
+			pipe.Expire(ctx, r.Prefix+g.groupKey, g.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.negative.Delete(key)
+
+	return nil
+}
+
+// Invalidate Remove every member of the group and the group itself.
+func (g *Group) Invalidate(ctx context.Context) error {
+	if g.store == nil {
+		return ErrUnsupportedStore
+	}
+	r := g.store
+	members, err := r.Redis.SMembers(ctx, r.Prefix+g.groupKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return r.Redis.Del(ctx, append(r.prefixKeys(members), r.Prefix+g.groupKey)...).Err()
+}
+
+// TTL Reset the TTL of every member of the group and the group itself.
+// Groups without a TTL make their keys persistent instead.
+func (g *Group) TTL(ctx context.Context) error {
+	if g.store == nil {
+		return ErrUnsupportedStore
+	}
+	r := g.store
+	members, err := r.Redis.SMembers(ctx, r.Prefix+g.groupKey).Result()
+	if err != nil {
+		return err
+	}
+	_, err = r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range append(r.prefixKeys(members), r.Prefix+g.groupKey) {
+			if g.ttl > 0 {
+				pipe.Expire(ctx, key, g.ttl)
+			} else {
+				pipe.Persist(ctx, key)
+			}
+		}
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,57 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	g := CacheGroup(r, "users", time.Minute)
+
+	_ = g.Put(ctx, "user:1", "a")
+	_ = g.Put(ctx, "user:2", "b")
+	m.FastForward(30 * time.Second)
+	if err := g.TTL(ctx); err != nil {
+		t.Fatalf("TTL: %v", err)
+	}
+	for _, key := range []string{"app:user:1", "app:user:2", "app:users"} {
+		if ttl := m.TTL(key); ttl != time.Minute {
+			t.Fatalf("TTL of %s = %v, want 1m", key, ttl)
+		}
+	}
+
+	if err := g.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	assertKeys(t, m)
+}
+
+func TestGroupWithoutTTL(t *testing.T) {
+	r, m := newTestStore(t)
+	ctx := context.Background()
+	g := CacheGroup(r, "users", 0)
+
+	_ = g.Put(ctx, "user:1", "a")
+	m.SetTTL("user:1", time.Minute)
+	if err := g.TTL(ctx); err != nil {
+		t.Fatalf("TTL: %v", err)
+	}
+	assertKeys(t, m, "user:1", "users")
+	if ttl := m.TTL("user:1"); ttl != 0 {
+		t.Fatalf("TTL of user:1 = %v, want none", ttl)
+	}
+}
+
+func TestGroupPutClearsNegativeCache(t *testing.T) {
+	r, _ := newTestStore(t, Config{NegativeCache: true, NegativeCacheTTL: time.Minute})
+	g := CacheGroup(r, "users", time.Minute)
+
+	r.Get("user:1", nil)
+	_ = g.Put(context.Background(), "user:1", "a")
+	if got := r.Get("user:1", nil); got != "a" {
+		t.Fatalf("Get after Group.Put = %v, want a", got)
+	}
+}
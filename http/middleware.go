@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// cachedResponse is the form in which a response is kept in the cache.
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// recorder captures a response while writing it through to the client.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// CacheMiddleware Cache successful GET responses in store for ttl, keyed by keyFn(req).
+// Requests carrying an Authorization header bypass the cache entirely, and responses that
+// set cookies, vary by request header or are marked private or no-store are not cached,
+// so personalised responses are never shared.
+func CacheMiddleware(store cache.Store, ttl time.Duration, keyFn func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodGet || req.Header.Get("Authorization") != "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			key := keyFn(req)
+			if cached, ok := load(store, key); ok {
+				for name, values := range cached.Header {
+					w.Header()[name] = values
+				}
+				w.WriteHeader(cached.Status)
+				_, _ = w.Write(cached.Body)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w}
+			next.ServeHTTP(rec, req)
+			if rec.status < 200 || rec.status >= 300 || !cacheable(w.Header()) {
+				return
+			}
+			header := w.Header().Clone()
+			header.Del("Set-Cookie")
+			payload, err := json.Marshal(cachedResponse{
+				Status: rec.status,
+				Header: header,
+				Body:   rec.body.Bytes(),
+			})
+			if err == nil {
+				_ = store.Put(key, string(payload), ttl)
+			}
+		})
+	}
+}
+
+// cacheable Check whether a response with the given headers may be shared between clients.
+func cacheable(header http.Header) bool {
+	if len(header.Values("Set-Cookie")) > 0 || len(header.Values("Vary")) > 0 {
+		return false
+	}
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name := strings.TrimSpace(strings.SplitN(directive, "=", 2)[0])
+			if strings.EqualFold(name, "private") || strings.EqualFold(name, "no-store") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// load Retrieve and decode the cached response stored at key.
+func load(store cache.Store, key string) (cachedResponse, bool) {
+	var cached cachedResponse
+	raw, ok := store.Get(key, nil).(string)
+	if !ok || raw == "" {
+		return cached, false
+	}
+	if err := json.Unmarshal([]byte(raw), &cached); err != nil {
+		return cached, false
+	}
+
+	return cached, true
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sujit-baniya/redisCache/testutil"
+)
+
+// serve Send a GET for path through h and return the recorded response.
+func serve(h http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// counting Create a handler that counts its calls and lets setHeaders adjust each response.
+func counting(calls *int, setHeaders func(http.Header)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*calls++
+		if setHeaders != nil {
+			setHeaders(w.Header())
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "hello")
+	})
+}
+
+func byPath(req *http.Request) string {
+	return "page:" + req.URL.Path
+}
+
+func TestCacheMiddlewareServesRepeatedGetsFromCache(t *testing.T) {
+	calls := 0
+	h := CacheMiddleware(testutil.NewMockStore(), time.Minute, byPath)(counting(&calls, nil))
+
+	first := serve(h, "/a", nil)
+	second := serve(h, "/a", nil)
+	if calls != 1 {
+		t.Fatalf("handler called %d times for two identical GETs, want 1", calls)
+	}
+	if second.Code != http.StatusOK || second.Body.String() != "hello" || second.Header().Get("Content-Type") != "text/plain" {
+		t.Fatalf("cached response = %d %q %v", second.Code, second.Body.String(), second.Header())
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Fatal("cached body differs from the original")
+	}
+
+	serve(h, "/a", http.Header{"Authorization": {"Bearer x"}})
+	if calls != 2 {
+		t.Fatal("request with Authorization was served from the cache")
+	}
+}
+
+func TestCacheMiddlewareSkipsPrivateResponses(t *testing.T) {
+	tests := map[string]func(http.Header){
+		"Set-Cookie":             func(h http.Header) { h.Set("Set-Cookie", "session=abc") },
+		"Vary":                   func(h http.Header) { h.Set("Vary", "Accept-Language") },
+		"Cache-Control private":  func(h http.Header) { h.Set("Cache-Control", "max-age=60, private") },
+		"Cache-Control no-store": func(h http.Header) { h.Set("Cache-Control", "no-store") },
+	}
+	for name, setHeaders := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			h := CacheMiddleware(testutil.NewMockStore(), time.Minute, byPath)(counting(&calls, setHeaders))
+
+			serve(h, "/a", nil)
+			serve(h, "/a", nil)
+			if calls != 2 {
+				t.Fatalf("handler called %d times, want the response not to be cached", calls)
+			}
+		})
+	}
+}
+
+func TestCacheMiddlewareCachesPublicResponses(t *testing.T) {
+	calls := 0
+	setHeaders := func(h http.Header) { h.Set("Cache-Control", "public, max-age=60") }
+	h := CacheMiddleware(testutil.NewMockStore(), time.Minute, byPath)(counting(&calls, setHeaders))
+
+	serve(h, "/a", nil)
+	serve(h, "/a", nil)
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
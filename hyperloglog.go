@@ -0,0 +1,16 @@
+package redisCache
+
+import (
+	"context"
+)
+
+// PFMerge Merge the HyperLogLogs stored at keys into dest.
+func (r *Redis) PFMerge(ctx context.Context, dest string, keys ...string) error {
+	return r.Redis.PFMerge(ctx, r.Prefix+dest, r.prefixKeys(keys)...).Err()
+}
+
+// PFMergeInto Merge the HyperLogLogs stored at sources into dest.
+// It is an alias of PFMerge that makes the destination explicit.
+func (r *Redis) PFMergeInto(ctx context.Context, dest string, sources ...string) error {
+	return r.PFMerge(ctx, dest, sources...)
+}
@@ -0,0 +1,24 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPFMergePrefixesDestination(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+
+	r.Redis.PFAdd(ctx, "app:a", "a", "b", "c")
+	r.Redis.PFAdd(ctx, "app:b", "d", "e")
+	if err := r.PFMergeInto(ctx, "all", "a", "b"); err != nil {
+		t.Fatalf("PFMergeInto: %v", err)
+	}
+	if m.Exists("all") {
+		t.Fatal("destination was written without the prefix")
+	}
+	n, err := r.Redis.PFCount(ctx, "app:all").Result()
+	if err != nil || n != 5 {
+		t.Fatalf("PFCount = %d, %v, want the union of 5", n, err)
+	}
+}
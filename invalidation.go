@@ -0,0 +1,52 @@
+package redisCache
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Invalidate Announce on Config.InvalidationChannel that keys are no longer valid.
+func (r *Redis) Invalidate(ctx context.Context, keys ...string) error {
+	payload, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	return r.Redis.Publish(ctx, r.config.InvalidationChannel, payload).Err()
+}
+
+// StartInvalidationSubscriber Subscribe to Config.InvalidationChannel and call onInvalidated
+// with the keys of every invalidation published by any instance. Locally cached misses for
+// those keys are dropped as well. The subscription ends when ctx is done.
+func (r *Redis) StartInvalidationSubscriber(ctx context.Context, onInvalidated func(keys []string)) error {
+	sub := r.Redis.Subscribe(ctx, r.config.InvalidationChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return err
+	}
+
+	go func() {
+		defer sub.Close()
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				var keys []string
+				if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+					continue
+				}
+				for _, key := range keys {
+					r.negative.Delete(key)
+				}
+				onInvalidated(keys)
+			}
+		}
+	}()
+
+	return nil
+}
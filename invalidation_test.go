@@ -0,0 +1,34 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInvalidationSubscriber(t *testing.T) {
+	r, _ := newTestStore(t, Config{NegativeCache: true, NegativeCacheTTL: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.Get("user:1", nil)
+	got := make(chan []string, 1)
+	if err := r.StartInvalidationSubscriber(ctx, func(keys []string) { got <- keys }); err != nil {
+		t.Fatalf("StartInvalidationSubscriber: %v", err)
+	}
+	if err := r.Invalidate(ctx, "user:1", "user:2"); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+
+	select {
+	case keys := <-got:
+		if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+			t.Fatalf("invalidated %v", keys)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no invalidation received")
+	}
+	if r.isNegative("user:1") {
+		t.Fatal("negative entry kept after invalidation")
+	}
+}
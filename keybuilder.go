@@ -0,0 +1,51 @@
+package redisCache
+
+import (
+	"strings"
+)
+
+// KeyBuilder assembles cache keys from named segments, e.g. "user:42:post:7".
+// The zero value joins segments with ":".
+type KeyBuilder struct {
+	Separator string
+	segments  []string
+}
+
+// User Append a user segment.
+func (b KeyBuilder) User(id string) *KeyBuilder {
+	return b.with("user", id)
+}
+
+// Tenant Append a tenant segment.
+func (b KeyBuilder) Tenant(id string) *KeyBuilder {
+	return b.with("tenant", id)
+}
+
+// Entity Append an entity name segment.
+func (b KeyBuilder) Entity(name string) *KeyBuilder {
+	return b.with(name)
+}
+
+// ID Append an identifier segment.
+func (b KeyBuilder) ID(id string) *KeyBuilder {
+	return b.with(id)
+}
+
+// Build Join the segments into a key.
+func (b KeyBuilder) Build() string {
+	sep := b.Separator
+	if sep == "" {
+		sep = ":"
+	}
+
+	return strings.Join(b.segments, sep)
+}
+
+// with Return a copy of the builder with parts appended, leaving b untouched.
+func (b KeyBuilder) with(parts ...string) *KeyBuilder {
+	segments := make([]string, 0, len(b.segments)+len(parts))
+	segments = append(segments, b.segments...)
+	b.segments = append(segments, parts...)
+
+	return &b
+}
@@ -0,0 +1,20 @@
+package redisCache
+
+import (
+	"testing"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	var b KeyBuilder
+	user := b.User("42")
+
+	if got := user.Entity("post").ID("7").Build(); got != "user:42:post:7" {
+		t.Fatalf("Build = %q, want user:42:post:7", got)
+	}
+	if got := user.Entity("comment").ID("9").Build(); got != "user:42:comment:9" {
+		t.Fatalf("Build from a shared prefix = %q, want user:42:comment:9", got)
+	}
+	if got := (KeyBuilder{Separator: "/"}).Tenant("acme").User("1").Build(); got != "tenant/acme/user/1" {
+		t.Fatalf("Build with a separator = %q", got)
+	}
+}
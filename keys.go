@@ -0,0 +1,340 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// LFUHint Check whether the LFU frequency of a key is below threshold,
+// marking it as a candidate for manual eviction. Requires an LFU maxmemory-policy.
+func (r *Redis) LFUHint(ctx context.Context, key string, threshold int64) (bool, error) {
+	freq, err := r.objectFreq(ctx, r.Prefix+key)
+	if err != nil {
+		return false, err
+	}
+
+	return freq < threshold, nil
+}
+
+// SweepLowFrequency Unlink every key matching pattern whose LFU frequency is below threshold.
+// It returns the number of keys removed.
+func (r *Redis) SweepLowFrequency(ctx context.Context, pattern string, threshold int64) (int64, error) {
+	var swept int64
+	iter := r.Redis.Scan(ctx, 0, escapeGlob(r.Prefix)+pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		freq, err := r.objectFreq(ctx, key)
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return swept, err
+		}
+		if freq >= threshold {
+			continue
+		}
+		n, err := r.Redis.Unlink(ctx, key).Result()
+		if err != nil {
+			return swept, err
+		}
+		swept += n
+	}
+
+	return swept, iter.Err()
+}
+
+func (r *Redis) objectFreq(ctx context.Context, key string) (int64, error) {
+	return r.Redis.Do(ctx, "object", "freq", key).Int64()
+}
+
+// FlushVersion Remove every key stored under the given namespace version.
+func (r *Redis) FlushVersion(ctx context.Context, version int) error {
+	if version <= 0 {
+		return ErrInvalidVersion
+	}
+	_, err := r.deleteMatching(ctx, escapeGlob(versionPrefix(r.config.Prefix, version))+"*")
+	return err
+}
+
+// CopyToDb Copy the value at src into dst in the database destDB.
+// The prefix is applied to src only, since the destination database may use another
+// naming scheme. Without replace an existing dst yields ErrNotCopied.
+func (r *Redis) CopyToDb(ctx context.Context, src, dst string, destDB int, replace bool) error {
+	n, err := r.Redis.Copy(ctx, r.Prefix+src, dst, destDB, replace).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotCopied
+	}
+
+	return nil
+}
+
+// ExpireTime Retrieve the absolute time at which key expires, with second precision.
+// Persistent keys yield the zero time and missing keys redis.Nil. Servers older than
+// Redis 7.0 derive it from PTTL and the local clock.
+func (r *Redis) ExpireTime(ctx context.Context, key string) (time.Time, error) {
+	ts, err := r.expireTime(ctx, "expiretime", key)
+	if err != nil || ts <= 0 {
+		return time.Time{}, err
+	}
+
+	return time.Unix(ts, 0), nil
+}
+
+// PExpireTime Retrieve the absolute time at which key expires, with millisecond precision.
+// Persistent keys yield the zero time and missing keys redis.Nil. Servers older than
+// Redis 7.0 derive it from PTTL and the local clock.
+func (r *Redis) PExpireTime(ctx context.Context, key string) (time.Time, error) {
+	ts, err := r.expireTime(ctx, "pexpiretime", key)
+	if err != nil || ts <= 0 {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(ts), nil
+}
+
+func (r *Redis) expireTime(ctx context.Context, cmd, key string) (int64, error) {
+	if !r.features.ExpireTime {
+		return r.expireTimeFallback(ctx, cmd, key)
+	}
+
+	ts, err := r.Redis.Do(ctx, cmd, r.Prefix+key).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if ts == -2 {
+		return 0, redis.Nil
+	}
+
+	return ts, nil
+}
+
+func (r *Redis) expireTimeFallback(ctx context.Context, cmd, key string) (int64, error) {
+	ttl, err := r.Redis.PTTL(ctx, r.Prefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	switch ttl {
+	case -2:
+		return 0, redis.Nil
+	case -1:
+		return -1, nil
+	}
+	at := time.Now().Add(ttl)
+	if cmd == "pexpiretime" {
+		return at.UnixMilli(), nil
+	}
+
+	return at.Unix(), nil
+}
+
+// ForgetByPattern Remove every item whose key matches pattern and return how many were removed.
+// Keys are collected with SCAN and deleted in pipelined batches, so the server is never
+// blocked the way KEYS would block it.
+func (r *Redis) ForgetByPattern(ctx context.Context, pattern string) (int64, error) {
+	return r.deleteMatching(ctx, escapeGlob(r.Prefix)+pattern)
+}
+
+// escapeGlob Escape the glob metacharacters of s so a SCAN pattern matches it literally.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
+// deleteMatching Delete every key matching the raw SCAN pattern match in pipelined batches.
+func (r *Redis) deleteMatching(ctx context.Context, match string) (int64, error) {
+	const batchSize = 500
+	var deleted int64
+	flush := func(batch []string) error {
+		cmds, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i := 0; i < len(batch); i += 100 {
+				end := i + 100
+				if end > len(batch) {
+					end = len(batch)
+				}
+				pipe.Del(ctx, batch[i:end]...)
+			}
+			return nil
+		})
+		for _, cmd := range cmds {
+			deleted += cmd.(*redis.IntCmd).Val()
+		}
+		return err
+	}
+
+	iter := r.Redis.Scan(ctx, 0, match, batchSize).Iterator()
+	batch := make([]string, 0, batchSize)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == batchSize {
+			if err := flush(batch); err != nil {
+				return deleted, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	if len(batch) > 0 {
+		if err := flush(batch); err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// CopyPrefix Copy every key starting with srcPrefix to the same key under dstPrefix,
+// keeping its type and remaining TTL, and return how many keys were copied.
+// Existing destination keys are replaced. Keys are collected before copying so that, when
+// dstPrefix starts with srcPrefix, the copies are not themselves matched and copied again;
+// keys already under dstPrefix are skipped for the same reason.
+func (r *Redis) CopyPrefix(ctx context.Context, srcPrefix, dstPrefix string) (int64, error) {
+	src, dst := r.Prefix+srcPrefix, r.Prefix+dstPrefix
+	nested := dst != src && strings.HasPrefix(dst, src)
+	var keys []string
+	iter := r.Redis.Scan(ctx, 0, escapeGlob(src)+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		if nested && strings.HasPrefix(iter.Val(), dst) {
+			continue
+		}
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return 0, err
+	}
+
+	var copied int64
+	for _, key := range keys {
+		dump, err := r.Redis.Dump(ctx, key).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return copied, err
+		}
+		ttl, err := r.Redis.PTTL(ctx, key).Result()
+		if err != nil {
+			return copied, err
+		}
+		if ttl < 0 {
+			ttl = 0
+		}
+		if err := r.Redis.RestoreReplace(ctx, dst+strings.TrimPrefix(key, src), ttl, dump).Err(); err != nil {
+			return copied, err
+		}
+		copied++
+	}
+
+	return copied, nil
+}
+
+// ScanAndApply Call fn with successive batches of up to batchSize keys matching pattern,
+// prefix stripped, without loading the whole keyspace into memory. Iteration stops at
+// the first error returned by fn.
+func (r *Redis) ScanAndApply(ctx context.Context, pattern string, batchSize int64, fn func(ctx context.Context, keys []string) error) error {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	iter := r.Redis.Scan(ctx, 0, escapeGlob(r.Prefix)+pattern, batchSize).Iterator()
+	batch := make([]string, 0, batchSize)
+	for iter.Next(ctx) {
+		batch = append(batch, strings.TrimPrefix(iter.Val(), r.Prefix))
+		if int64(len(batch)) == batchSize {
+			if err := fn(ctx, batch); err != nil {
+				return err
+			}
+			batch = make([]string, 0, batchSize)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(ctx, batch)
+	}
+
+	return nil
+}
+
+// Migrate Move key to the database db of the Redis instance at host:port.
+// The key is removed locally once the target has acknowledged it.
+func (r *Redis) Migrate(ctx context.Context, host string, port string, key string, db int, timeout time.Duration) error {
+	return r.Redis.Migrate(ctx, host, port, r.Prefix+key, db, timeout).Err()
+}
+
+// CopyWithTTL Copy the value at src into dst in the same database. COPY keeps the
+// remaining TTL of src on dst. Without replace an existing dst yields ErrNotCopied.
+func (r *Redis) CopyWithTTL(ctx context.Context, src, dst string, replace bool) error {
+	n, err := r.Redis.Copy(ctx, r.Prefix+src, r.Prefix+dst, r.config.DB, replace).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotCopied
+	}
+
+	return nil
+}
+
+// copyPersistScript copies KEYS[1] to KEYS[2] and clears the TTL of the copy, leaving an
+// existing destination untouched when nothing was copied.
+var copyPersistScript = redis.NewScript(`
+local copied
+if ARGV[1] == '1' then
+	copied = redis.call('COPY', KEYS[1], KEYS[2], 'REPLACE')
+else
+	copied = redis.call('COPY', KEYS[1], KEYS[2])
+end
+if copied == 1 then
+	redis.call('PERSIST', KEYS[2])
+end
+return copied
+`)
+
+// CopyWithoutTTL Copy the value at src into dst in the same database and make dst persistent.
+// Both steps run atomically in a script. Without replace an existing dst yields ErrNotCopied.
+func (r *Redis) CopyWithoutTTL(ctx context.Context, src, dst string, replace bool) error {
+	flag := "0"
+	if replace {
+		flag = "1"
+	}
+	n, err := copyPersistScript.Run(ctx, r.Redis, []string{r.Prefix + src, r.Prefix + dst}, flag).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotCopied
+	}
+
+	return nil
+}
+
+// TouchTTL Reset the TTL of key to ttl without reading its value, implementing sliding expiry
+// for callers that do not need the value. PEXPIRE is used so sub-second TTLs are honoured.
+// Missing keys yield redis.Nil.
+func (r *Redis) TouchTTL(ctx context.Context, key string, ttl time.Duration) error {
+	ok, err := r.Redis.PExpire(ctx, r.Prefix+key, ttl).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return redis.Nil
+	}
+
+	return nil
+}
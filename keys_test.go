@@ -0,0 +1,428 @@
+package redisCache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+// stubObjectFreq Answer OBJECT FREQ on m from freqs, keyed by raw key.
+func stubObjectFreq(s *stubs, freqs map[string]int) {
+	s.on("OBJECT", func(c *server.Peer, args []string) {
+		freq, ok := freqs[args[1]]
+		if !ok {
+			c.WriteNull()
+			return
+		}
+		c.WriteInt(freq)
+	})
+}
+
+func TestLFUHint(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	stubObjectFreq(stubCommands(m), map[string]int{"app:hot": 200, "app:cold": 1})
+	ctx := context.Background()
+
+	if cold, err := r.LFUHint(ctx, "cold", 5); err != nil || !cold {
+		t.Fatalf("LFUHint(cold) = %v, %v, want true", cold, err)
+	}
+	if cold, err := r.LFUHint(ctx, "hot", 5); err != nil || cold {
+		t.Fatalf("LFUHint(hot) = %v, %v, want false", cold, err)
+	}
+}
+
+func TestSweepLowFrequency(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	for _, key := range []string{"app:user:1", "app:user:2", "app:user:3", "app:post:1"} {
+		m.Set(key, "v")
+	}
+	stubObjectFreq(stubCommands(m), map[string]int{"app:user:1": 1, "app:user:2": 50, "app:user:3": 0, "app:post:1": 0})
+
+	n, err := r.SweepLowFrequency(context.Background(), "user:*", 5)
+	if err != nil {
+		t.Fatalf("SweepLowFrequency: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("swept %d keys, want 2", n)
+	}
+	assertKeys(t, m, "app:post:1", "app:user:2")
+}
+
+// assertKeys Fail the test unless m holds exactly keys.
+func assertKeys(t *testing.T, m *miniredis.Miniredis, keys ...string) {
+	t.Helper()
+	got := m.Keys()
+	if len(got) != len(keys) {
+		t.Fatalf("keys = %v, want %v", got, keys)
+	}
+	for i := range keys {
+		if got[i] != keys[i] {
+			t.Fatalf("keys = %v, want %v", got, keys)
+		}
+	}
+}
+
+func TestVersionedKeysAreIsolated(t *testing.T) {
+	v1, m := newTestStore(t, Config{Prefix: "app", Version: 1})
+	store, err := New(Config{Host: m.Host(), Port: m.Port(), Prefix: "app", Version: 2})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	v2 := store.(*Redis)
+	defer v2.Redis.Close()
+
+	_ = v1.Put("user:1", "old", 0)
+	if v2.Has("user:1") {
+		t.Fatal("v1 key visible to a v2 store")
+	}
+	_ = v2.Put("user:1", "new", 0)
+	if got := v1.GetString("user:1", ""); got != "old" {
+		t.Fatalf("v1 value = %q, want old", got)
+	}
+
+	if err := v2.FlushVersion(context.Background(), 1); err != nil {
+		t.Fatalf("FlushVersion: %v", err)
+	}
+	assertKeys(t, m, "app:v2:user:1")
+}
+
+func TestScanPatternsEscapePrefix(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app[1]:"})
+	m.Set("app[1]:a", "v")
+	m.Set("app1:a", "v")
+	m.Set("app]:a", "v")
+
+	n, err := r.ForgetByPattern(context.Background(), "*")
+	if err != nil || n != 1 {
+		t.Fatalf("ForgetByPattern = %d, %v, want 1", n, err)
+	}
+	assertKeys(t, m, "app1:a", "app]:a")
+}
+
+func TestCopyToDb(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	m.Set("app:src", "v")
+
+	if err := r.CopyToDb(ctx, "src", "dst", 3, false); err != nil {
+		t.Fatalf("CopyToDb: %v", err)
+	}
+	if got, _ := m.DB(3).Get("dst"); got != "v" {
+		t.Fatalf("copied value = %q, want v", got)
+	}
+	if err := r.CopyToDb(ctx, "src", "dst", 3, false); err != ErrNotCopied {
+		t.Fatalf("CopyToDb onto an existing key = %v, want ErrNotCopied", err)
+	}
+	if err := r.CopyToDb(ctx, "src", "dst", 3, true); err != nil {
+		t.Fatalf("CopyToDb with replace: %v", err)
+	}
+	if err := r.CopyToDb(ctx, "missing", "other", 3, false); err != ErrNotCopied {
+		t.Fatalf("CopyToDb of a missing key = %v, want ErrNotCopied", err)
+	}
+}
+
+func TestExpireTime(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	m.SetTime(now)
+	m.Set("app:k", "v")
+	m.SetTTL("app:k", 90*time.Second)
+	m.Set("app:forever", "v")
+
+	if at, err := r.ExpireTime(ctx, "k"); err != nil || !at.Equal(now.Add(90*time.Second)) {
+		t.Fatalf("ExpireTime = %v, %v", at, err)
+	}
+	if at, err := r.PExpireTime(ctx, "k"); err != nil || !at.Equal(now.Add(90*time.Second)) {
+		t.Fatalf("PExpireTime = %v, %v", at, err)
+	}
+	if at, err := r.ExpireTime(ctx, "forever"); err != nil || !at.IsZero() {
+		t.Fatalf("ExpireTime of a persistent key = %v, %v, want the zero time", at, err)
+	}
+	if _, err := r.ExpireTime(ctx, "missing"); err != redis.Nil {
+		t.Fatalf("ExpireTime of a missing key = %v, want redis.Nil", err)
+	}
+}
+
+func TestExpireTimeFallback(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	r.features.ExpireTime = false
+	ctx := context.Background()
+	m.Set("app:k", "v")
+	m.SetTTL("app:k", 90*time.Second)
+	m.Set("app:forever", "v")
+
+	want := time.Now().Add(90 * time.Second)
+	at, err := r.PExpireTime(ctx, "k")
+	if err != nil || at.Before(want.Add(-time.Second)) || at.After(want.Add(time.Second)) {
+		t.Fatalf("PExpireTime = %v, %v, want about %v", at, err, want)
+	}
+	if at, err := r.ExpireTime(ctx, "forever"); err != nil || !at.IsZero() {
+		t.Fatalf("ExpireTime of a persistent key = %v, %v, want the zero time", at, err)
+	}
+	if _, err := r.ExpireTime(ctx, "missing"); err != redis.Nil {
+		t.Fatalf("ExpireTime of a missing key = %v, want redis.Nil", err)
+	}
+}
+
+func TestForgetByPattern(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	for i := 0; i < 1200; i++ {
+		m.Set("app:tmp:"+strconv.Itoa(i), "v")
+	}
+	m.Set("app:keep", "v")
+	m.Set("tmp:other", "v")
+
+	n, err := r.ForgetByPattern(context.Background(), "tmp:*")
+	if err != nil || n != 1200 {
+		t.Fatalf("ForgetByPattern = %d, %v, want 1200", n, err)
+	}
+	assertKeys(t, m, "app:keep", "tmp:other")
+}
+
+// stubDumpRestore Emulate DUMP and RESTORE REPLACE on m for every data type, as miniredis
+// only serializes strings. The dump of a key is its name.
+func stubDumpRestore(m *miniredis.Miniredis, s *stubs) {
+	s.on("DUMP", func(c *server.Peer, args []string) {
+		if !m.Exists(args[0]) {
+			c.WriteNull()
+			return
+		}
+		c.WriteBulk(args[0])
+	})
+	s.on("RESTORE", func(c *server.Peer, args []string) {
+		dst, src := args[0], args[2]
+		m.Del(dst)
+		switch m.Type(src) {
+		case "string":
+			v, _ := m.Get(src)
+			m.Set(dst, v)
+		case "hash":
+			keys, _ := m.HKeys(src)
+			for _, f := range keys {
+				m.HSet(dst, f, m.HGet(src, f))
+			}
+		case "list":
+			items, _ := m.List(src)
+			_, _ = m.Push(dst, items...)
+		case "set":
+			members, _ := m.Members(src)
+			_, _ = m.SetAdd(dst, members...)
+		case "zset":
+			members, _ := m.SortedSet(src)
+			for member, score := range members {
+				_, _ = m.ZAdd(dst, score, member)
+			}
+		}
+		if ms, _ := strconv.Atoi(args[1]); ms > 0 {
+			m.SetTTL(dst, time.Duration(ms)*time.Millisecond)
+		}
+		c.WriteOK()
+	})
+}
+
+func TestCopyPrefix(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	stubDumpRestore(m, stubCommands(m))
+	ctx := context.Background()
+	m.Set("app:v1:str", "s")
+	m.SetTTL("app:v1:str", time.Minute)
+	m.HSet("app:v1:hash", "f", "h")
+	_, _ = m.Push("app:v1:list", "a", "b")
+	_, _ = m.SetAdd("app:v1:set", "x")
+	_, _ = m.ZAdd("app:v1:zset", 1.5, "z")
+
+	n, err := r.CopyPrefix(ctx, "v1:", "v2:")
+	if err != nil || n != 5 {
+		t.Fatalf("CopyPrefix = %d, %v, want 5", n, err)
+	}
+	for _, key := range []string{"str", "hash", "list", "set", "zset"} {
+		if got, want := m.Type("app:v2:"+key), m.Type("app:v1:"+key); got != want {
+			t.Fatalf("type of v2:%s = %q, want %q", key, got, want)
+		}
+	}
+	if got := m.HGet("app:v2:hash", "f"); got != "h" {
+		t.Fatalf("copied hash field = %q", got)
+	}
+	if score, _ := m.ZScore("app:v2:zset", "z"); score != 1.5 {
+		t.Fatalf("copied score = %v", score)
+	}
+	if ttl := m.TTL("app:v2:str"); ttl != time.Minute {
+		t.Fatalf("copied TTL = %v, want 1m", ttl)
+	}
+	if ttl := m.TTL("app:v2:hash"); ttl != 0 {
+		t.Fatalf("copied TTL of a persistent key = %v", ttl)
+	}
+}
+
+func TestCopyPrefixOverlapping(t *testing.T) {
+	r, m := newTestStore(t)
+	m.Set("v1:a", "1")
+	m.Set("v1:b", "2")
+
+	n, err := r.CopyPrefix(context.Background(), "v1", "v1-next")
+	if err != nil || n != 2 {
+		t.Fatalf("CopyPrefix = %d, %v, want 2", n, err)
+	}
+	n, err = r.CopyPrefix(context.Background(), "v1", "v1-next")
+	if err != nil || n != 2 {
+		t.Fatalf("second CopyPrefix = %d, %v, want 2", n, err)
+	}
+	assertKeys(t, m, "v1-next:a", "v1-next:b", "v1:a", "v1:b")
+}
+
+func TestMigrate(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	s.on("MIGRATE", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.Migrate(context.Background(), "10.0.0.2", "6380", "k", 3, 2*time.Second); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "MIGRATE"), "10.0.0.2", "6380", "app:k", "3", "2000")
+}
+
+func TestCopyWithTTL(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	m.Set("app:src", "v")
+	m.SetTTL("app:src", time.Minute)
+	m.Set("app:taken", "old")
+
+	if err := r.CopyWithTTL(ctx, "src", "dst", false); err != nil {
+		t.Fatalf("CopyWithTTL: %v", err)
+	}
+	if got, _ := m.Get("app:dst"); got != "v" {
+		t.Fatalf("copy = %q, want v", got)
+	}
+	if ttl := m.TTL("app:dst"); ttl != time.Minute {
+		t.Fatalf("copy TTL = %v, want the 1m TTL of the source", ttl)
+	}
+	if err := r.CopyWithTTL(ctx, "src", "taken", false); err != ErrNotCopied {
+		t.Fatalf("CopyWithTTL onto an existing key = %v, want ErrNotCopied", err)
+	}
+	if err := r.CopyWithTTL(ctx, "src", "taken", true); err != nil {
+		t.Fatalf("CopyWithTTL with replace: %v", err)
+	}
+	if got, _ := m.Get("app:taken"); got != "v" {
+		t.Fatalf("replaced copy = %q, want v", got)
+	}
+}
+
+func TestCopyWithoutTTL(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	m.Set("app:src", "v")
+	m.SetTTL("app:src", time.Minute)
+	m.Set("app:taken", "old")
+	m.SetTTL("app:taken", time.Hour)
+
+	if err := r.CopyWithoutTTL(ctx, "src", "dst", false); err != nil {
+		t.Fatalf("CopyWithoutTTL: %v", err)
+	}
+	if got, _ := m.Get("app:dst"); got != "v" {
+		t.Fatalf("copy = %q, want v", got)
+	}
+	if ttl := m.TTL("app:dst"); ttl != 0 {
+		t.Fatalf("copy TTL = %v, want none", ttl)
+	}
+	if err := r.CopyWithoutTTL(ctx, "src", "taken", false); err != ErrNotCopied {
+		t.Fatalf("CopyWithoutTTL onto an existing key = %v, want ErrNotCopied", err)
+	}
+	if ttl := m.TTL("app:taken"); ttl != time.Hour {
+		t.Fatalf("TTL of the untouched destination = %v, want 1h", ttl)
+	}
+}
+
+func TestTouchTTL(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	m.Set("app:k", "v")
+	m.SetTTL("app:k", time.Second)
+
+	if err := r.TouchTTL(ctx, "k", 1500*time.Millisecond); err != nil {
+		t.Fatalf("TouchTTL: %v", err)
+	}
+	if ttl := m.TTL("app:k"); ttl != 1500*time.Millisecond {
+		t.Fatalf("TTL = %v, want 1.5s", ttl)
+	}
+	if got, _ := m.Get("app:k"); got != "v" {
+		t.Fatalf("value = %q, want it untouched", got)
+	}
+	if err := r.TouchTTL(ctx, "missing", time.Minute); err != redis.Nil {
+		t.Fatalf("TouchTTL of a missing key = %v, want redis.Nil", err)
+	}
+}
+
+func TestScanAndApply(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	for i := 0; i < 25; i++ {
+		m.Set("app:user:"+strconv.Itoa(i), "v")
+	}
+	m.Set("app:other", "v")
+	m.Set("user:outside", "v")
+
+	var batches int
+	seen := map[string]bool{}
+	err := r.ScanAndApply(ctx, "user:*", 10, func(ctx context.Context, keys []string) error {
+		batches++
+		if len(keys) > 10 {
+			t.Fatalf("batch of %d keys, want at most 10", len(keys))
+		}
+		for _, key := range keys {
+			if !strings.HasPrefix(key, "user:") {
+				t.Fatalf("key %q does not have the prefix stripped", key)
+			}
+			seen[key] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanAndApply: %v", err)
+	}
+	if len(seen) != 25 || batches < 3 {
+		t.Fatalf("ScanAndApply saw %d keys in %d batches, want 25 in at least 3", len(seen), batches)
+	}
+}
+
+func TestScanAndApplyStopsOnError(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	for i := 0; i < 25; i++ {
+		m.Set("app:k"+strconv.Itoa(i), "v")
+	}
+	errStop := errors.New("stop")
+
+	calls := 0
+	err := r.ScanAndApply(context.Background(), "*", 5, func(ctx context.Context, keys []string) error {
+		calls++
+		return errStop
+	})
+	if err != errStop || calls != 1 {
+		t.Fatalf("ScanAndApply = %v after %d calls, want the error of fn after 1", err, calls)
+	}
+}
+
+func TestScanAndApplyEscapesPrefix(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app[1]*:"})
+	m.Set("app[1]*:k", "v")
+	m.Set("app1:k", "v")
+	m.Set("app[1]x:k", "v")
+
+	var keys []string
+	err := r.ScanAndApply(context.Background(), "*", 10, func(ctx context.Context, batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanAndApply: %v", err)
+	}
+	equalArgs(t, keys, "k")
+}
@@ -0,0 +1,100 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// LMPop Pop up to count elements from the first non-empty list among keys.
+// direction is "LEFT" or "RIGHT". It returns the key the elements were popped from,
+// or redis.Nil when every list is empty. Servers older than Redis 7.0 fall back to
+// popping each list in turn, which is not atomic across keys.
+func (r *Redis) LMPop(ctx context.Context, count int64, direction string, keys ...string) (string, []string, error) {
+	if !r.features.LMPop {
+		return r.lmpopFallback(ctx, count, direction, keys)
+	}
+
+	args := []interface{}{"lmpop", len(keys)}
+	for _, key := range keys {
+		args = append(args, r.Prefix+key)
+	}
+	args = append(args, direction, "count", count)
+
+	res, err := r.Redis.Do(ctx, args...).Slice()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(res) != 2 {
+		return "", nil, redis.Nil
+	}
+	key, _ := res[0].(string)
+	items, _ := res[1].([]interface{})
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+
+	return strings.TrimPrefix(key, r.Prefix), values, nil
+}
+
+func (r *Redis) lmpopFallback(ctx context.Context, count int64, direction string, keys []string) (string, []string, error) {
+	left := strings.EqualFold(direction, "left")
+	for _, key := range keys {
+		var values []string
+		for int64(len(values)) < count {
+			var val string
+			var err error
+			if left {
+				val, err = r.Redis.LPop(ctx, r.Prefix+key).Result()
+			} else {
+				val, err = r.Redis.RPop(ctx, r.Prefix+key).Result()
+			}
+			if err == redis.Nil {
+				break
+			}
+			if err != nil {
+				return "", nil, err
+			}
+			values = append(values, val)
+		}
+		if len(values) > 0 {
+			return key, values, nil
+		}
+	}
+
+	return "", nil, redis.Nil
+}
+
+// listContainsScript scans a list for an element on servers without LPOS.
+var listContainsScript = redis.NewScript(`
+local items = redis.call('LRANGE', KEYS[1], 0, -1)
+for _, item in ipairs(items) do
+	if item == ARGV[1] then
+		return 1
+	end
+end
+return 0
+`)
+
+// ListContains Check whether element is in the list at key without transferring the list.
+// Missing keys contain nothing. Servers older than Redis 6.0.6 scan the list in a script.
+func (r *Redis) ListContains(ctx context.Context, key string, element string) (bool, error) {
+	_, err := r.Redis.LPos(ctx, r.Prefix+key, element, redis.LPosArgs{}).Result()
+	if err == nil {
+		return true, nil
+	}
+	if err == redis.Nil {
+		return false, nil
+	}
+	if !isUnknownCommand(err) {
+		return false, err
+	}
+
+	n, err := listContainsScript.Run(ctx, r.Redis, []string{r.Prefix + key}, element).Int64()
+
+	return n == 1, err
+}
@@ -0,0 +1,75 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestLMPop(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	s.on("LMPOP", func(c *server.Peer, args []string) {
+		c.WriteLen(2)
+		c.WriteBulk("app:b")
+		c.WriteStrings([]string{"x", "y"})
+	})
+
+	key, values, err := r.LMPop(context.Background(), 2, "LEFT", "a", "b")
+	if err != nil {
+		t.Fatalf("LMPop: %v", err)
+	}
+	if key != "b" || len(values) != 2 || values[0] != "x" {
+		t.Fatalf("LMPop = %q, %v", key, values)
+	}
+	equalArgs(t, s.lastCall(t, "LMPOP"), "2", "app:a", "app:b", "LEFT", "count", "2")
+}
+
+func TestLMPopFallback(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	r.features.LMPop = false
+	ctx := context.Background()
+	_, _ = m.Push("app:b", "1", "2", "3")
+
+	key, values, err := r.LMPop(ctx, 2, "RIGHT", "a", "b")
+	if err != nil {
+		t.Fatalf("LMPop: %v", err)
+	}
+	if key != "b" || len(values) != 2 || values[0] != "3" || values[1] != "2" {
+		t.Fatalf("LMPop = %q, %v, want b [3 2]", key, values)
+	}
+
+	_, _, _ = r.LMPop(ctx, 5, "LEFT", "a", "b")
+	if _, _, err := r.LMPop(ctx, 1, "LEFT", "a", "b"); err != redis.Nil {
+		t.Fatalf("LMPop on empty lists = %v, want redis.Nil", err)
+	}
+}
+
+func TestListContains(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	_, _ = m.RPush("app:l", "a", "b", "c")
+
+	check := func(name string) {
+		t.Helper()
+		if ok, err := r.ListContains(ctx, "l", "b"); err != nil || !ok {
+			t.Fatalf("%s: ListContains b = %v, %v, want true", name, ok, err)
+		}
+		if ok, err := r.ListContains(ctx, "l", "z"); err != nil || ok {
+			t.Fatalf("%s: ListContains z = %v, %v, want false", name, ok, err)
+		}
+		if ok, err := r.ListContains(ctx, "missing", "a"); err != nil || ok {
+			t.Fatalf("%s: ListContains on a missing key = %v, %v, want false", name, ok, err)
+		}
+	}
+	check("LPOS")
+
+	s := stubCommands(m)
+	s.on("LPOS", func(c *server.Peer, args []string) { c.WriteError("ERR unknown command 'LPOS'") })
+	check("script fallback")
+	if len(s.called("EVALSHA"))+len(s.called("EVAL")) == 0 {
+		t.Fatal("the script fallback was not used")
+	}
+}
@@ -0,0 +1,43 @@
+package redisCache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// EncodingMonitor Poll the OBJECT ENCODING of key every Config.EncodingPollInterval and
+// call onChange whenever it changes, e.g. when a listpack is promoted to a quicklist.
+// Polling stops when ctx is done, the returned cancel function is called or key no longer
+// exists; other errors are retried on the next tick.
+func (r *Redis) EncodingMonitor(ctx context.Context, key string, onChange func(oldEnc, newEnc string)) (func(), error) {
+	current, err := r.Redis.ObjectEncoding(ctx, r.Prefix+key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(r.config.EncodingPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				enc, err := r.Redis.ObjectEncoding(ctx, r.Prefix+key).Result()
+				if err == redis.Nil {
+					return
+				}
+				if err != nil || enc == current {
+					continue
+				}
+				onChange(current, enc)
+				current = enc
+			}
+		}
+	}()
+
+	return cancel, nil
+}
@@ -0,0 +1,84 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// stubObjectEncoding Answer OBJECT ENCODING for hashes like a server with the default
+// hash-max-listpack-entries of 128.
+func stubObjectEncoding(m *miniredis.Miniredis, s *stubs) {
+	s.on("OBJECT", func(c *server.Peer, args []string) {
+		fields, err := m.HKeys(args[1])
+		switch {
+		case err != nil:
+			c.WriteNull()
+		case len(fields) > 128:
+			c.WriteBulk("hashtable")
+		default:
+			c.WriteBulk("listpack")
+		}
+	})
+}
+
+func TestEncodingMonitor(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:", EncodingPollInterval: 5 * time.Millisecond})
+	s := stubCommands(m)
+	stubObjectEncoding(m, s)
+	m.HSet("app:h", "f0", "v")
+
+	changes := make(chan [2]string, 10)
+	cancel, err := r.EncodingMonitor(context.Background(), "h", func(oldEnc, newEnc string) {
+		changes <- [2]string{oldEnc, newEnc}
+	})
+	if err != nil {
+		t.Fatalf("EncodingMonitor: %v", err)
+	}
+	for i := 1; i <= 200; i++ {
+		m.HSet("app:h", "f"+strconv.Itoa(i), "v")
+	}
+	select {
+	case change := <-changes:
+		if change != [2]string{"listpack", "hashtable"} {
+			t.Fatalf("onChange(%q, %q), want listpack to hashtable", change[0], change[1])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("onChange was not called after the promotion")
+	}
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	for i := 1; i <= 200; i++ {
+		m.HDel("app:h", "f"+strconv.Itoa(i))
+	}
+	select {
+	case change := <-changes:
+		t.Fatalf("onChange(%q, %q) after cancel", change[0], change[1])
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEncodingMonitorStopsWhenKeyIsDeleted(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:", EncodingPollInterval: 5 * time.Millisecond})
+	s := stubCommands(m)
+	stubObjectEncoding(m, s)
+	m.HSet("app:h", "f0", "v")
+
+	cancel, err := r.EncodingMonitor(context.Background(), "h", func(oldEnc, newEnc string) {})
+	if err != nil {
+		t.Fatalf("EncodingMonitor: %v", err)
+	}
+	defer cancel()
+	m.Del("app:h")
+	time.Sleep(30 * time.Millisecond)
+	polls := len(s.called("OBJECT"))
+	time.Sleep(30 * time.Millisecond)
+	if n := len(s.called("OBJECT")); n != polls {
+		t.Fatalf("OBJECT ENCODING sent %d more times after the key was deleted", n-polls)
+	}
+}
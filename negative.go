@@ -0,0 +1,51 @@
+package redisCache
+
+import (
+	"time"
+)
+
+// isNegative Check whether key was recently recorded as missing.
+func (r *Redis) isNegative(key string) bool {
+	if !r.config.NegativeCache {
+		return false
+	}
+	expiry, ok := r.negative.Load(key)
+	if !ok {
+		return false
+	}
+	if r.config.Clock.Now().After(expiry.(time.Time)) {
+		r.negative.Delete(key)
+		return false
+	}
+
+	return true
+}
+
+// markNegative Record key as missing for the negative cache TTL.
+func (r *Redis) markNegative(key string) {
+	if !r.config.NegativeCache || r.config.NegativeCacheTTL <= 0 {
+		return
+	}
+	now := r.config.Clock.Now()
+	r.negative.Store(key, now.Add(r.config.NegativeCacheTTL))
+	r.sweepNegative(now)
+}
+
+// sweepNegative Remove expired entries, at most once per negative cache TTL, so keys that
+// are missed once and never read again do not accumulate.
+func (r *Redis) sweepNegative(now time.Time) {
+	r.negativeMu.Lock()
+	if now.Before(r.negativeSweep) {
+		r.negativeMu.Unlock()
+		return
+	}
+	r.negativeSweep = now.Add(r.config.NegativeCacheTTL)
+	r.negativeMu.Unlock()
+
+	r.negative.Range(func(key, expiry interface{}) bool {
+		if now.After(expiry.(time.Time)) {
+			r.negative.Delete(key)
+		}
+		return true
+	})
+}
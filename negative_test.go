@@ -0,0 +1,82 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newNegativeStore(t *testing.T) (*Redis, *stubs, *ManualClock) {
+	t.Helper()
+	clock := NewManualClock(time.Now())
+	r, m := newTestStore(t, Config{NegativeCache: true, NegativeCacheTTL: time.Minute, Clock: clock})
+
+	return r, stubCommands(m), clock
+}
+
+func TestNegativeCacheSkipsRedis(t *testing.T) {
+	r, s, clock := newNegativeStore(t)
+
+	for i := 0; i < 5; i++ {
+		if got := r.Get("missing", "def"); got != "def" {
+			t.Fatalf("Get = %v, want def", got)
+		}
+	}
+	if n := len(s.called("GET")); n != 1 {
+		t.Fatalf("%d GETs sent for repeated misses, want 1", n)
+	}
+
+	_ = clock.Advance(time.Minute + time.Second)
+	r.Get("missing", nil)
+	if n := len(s.called("GET")); n != 2 {
+		t.Fatalf("%d GETs sent after the entry expired, want 2", n)
+	}
+}
+
+func TestNegativeCacheClearedByWrites(t *testing.T) {
+	r, _, _ := newNegativeStore(t)
+	ctx := context.Background()
+
+	r.Get("put", nil)
+	_ = r.Put("put", "v", 0)
+	if got := r.Get("put", nil); got != "v" {
+		t.Fatalf("Get after Put = %v", got)
+	}
+
+	r.Get("update", nil)
+	if err := r.AtomicUpdate(ctx, "update", 0, func(string) (string, error) { return "v", nil }); err != nil {
+		t.Fatalf("AtomicUpdate: %v", err)
+	}
+	if got := r.Get("update", nil); got != "v" {
+		t.Fatalf("Get after AtomicUpdate = %v", got)
+	}
+
+	r.Get("batch", nil)
+	w := NewBatchWriter(r, 0, 0)
+	w.Write("batch", "v", 0)
+	if err := w.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := r.Get("batch", nil); got != "v" {
+		t.Fatalf("Get after BatchWriter.Flush = %v", got)
+	}
+}
+
+func TestNegativeCacheSweepsExpiredEntries(t *testing.T) {
+	r, _, clock := newNegativeStore(t)
+
+	for _, key := range []string{"a", "b", "c"} {
+		r.Get(key, nil)
+	}
+	_ = clock.Advance(2 * time.Minute)
+	r.Get("d", nil)
+
+	var keys []interface{}
+	r.negative.Range(func(key, _ interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 1 || keys[0] != "d" {
+		t.Fatalf("negative entries = %v, want [d]", keys)
+	}
+}
@@ -0,0 +1,93 @@
+package redisCache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Message is a structured Pub/Sub message, JSON-encoded on the wire.
+type Message struct {
+	ID        string    `json:"id"`
+	Topic     string    `json:"topic"`
+	Payload   []byte    `json:"payload"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PublishMessage Publish msg on channel. A missing ID is filled with a random UUID, a missing
+// Topic with channel and a zero Timestamp with the current time.
+func (r *Redis) PublishMessage(ctx context.Context, channel string, msg Message) error {
+	if msg.ID == "" {
+		id, err := newUUID()
+		if err != nil {
+			return err
+		}
+		msg.ID = id
+	}
+	if msg.Topic == "" {
+		msg.Topic = channel
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = r.config.Clock.Now()
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return r.Redis.Publish(ctx, channel, payload).Err()
+}
+
+// SubscribeMessages Subscribe to channel and deliver the decoded messages in the order they
+// were published. Payloads that are not messages are skipped. The returned function ends the
+// subscription and closes the channel, as does cancelling ctx.
+func (r *Redis) SubscribeMessages(ctx context.Context, channel string) (<-chan Message, func(), error) {
+	sub := r.Redis.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan Message)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-ch:
+				if !ok {
+					return
+				}
+				var msg Message
+				if err := json.Unmarshal([]byte(raw.Payload), &msg); err != nil {
+					continue
+				}
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, cancel, nil
+}
+
+// newUUID Generate a random version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
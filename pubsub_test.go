@@ -0,0 +1,52 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPublishSubscribeMessages(t *testing.T) {
+	r, m := newTestStore(t)
+	ctx := context.Background()
+	msgs, stop, err := r.SubscribeMessages(ctx, "events")
+	if err != nil {
+		t.Fatalf("SubscribeMessages: %v", err)
+	}
+	receive := func() Message {
+		t.Helper()
+		select {
+		case msg := <-msgs:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("no message received")
+		}
+		return Message{}
+	}
+
+	m.Publish("events", "not a message")
+	if err := r.PublishMessage(ctx, "events", Message{Payload: []byte("first")}); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	_ = r.PublishMessage(ctx, "events", Message{ID: "id-2", Topic: "custom", Payload: []byte("second"), Timestamp: at})
+
+	first := receive()
+	if string(first.Payload) != "first" || len(first.ID) != 36 || first.Topic != "events" || first.Timestamp.IsZero() {
+		t.Fatalf("first message = %+v", first)
+	}
+	second := receive()
+	if second.ID != "id-2" || second.Topic != "custom" || string(second.Payload) != "second" || !second.Timestamp.Equal(at) {
+		t.Fatalf("second message = %+v", second)
+	}
+
+	stop()
+	select {
+	case _, ok := <-msgs:
+		if ok {
+			t.Fatal("message received after stop")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after stop")
+	}
+}
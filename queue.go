@@ -0,0 +1,130 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// WorkQueue distributes jobs pushed onto Redis lists to workers.
+type WorkQueue struct {
+	store *Redis
+	next  uint32
+}
+
+// NewWorkQueue Create a WorkQueue whose queues live in store.
+// The store must be created by New; with other stores every method returns ErrUnsupportedStore.
+func NewWorkQueue(store cache.Store) *WorkQueue {
+	r, _ := store.(*Redis)
+	return &WorkQueue{store: r}
+}
+
+// Enqueue Append a job to the tail of queue.
+func (q *WorkQueue) Enqueue(ctx context.Context, queue string, job interface{}) error {
+	if q.store == nil {
+		return ErrUnsupportedStore
+	}
+
+	return q.store.Redis.RPush(ctx, q.store.Prefix+queue, job).Err()
+}
+
+// Dequeue Pop the oldest job from the first non-empty queue, waiting up to timeout.
+// The queue checked first rotates on every call so busy queues cannot starve the others.
+// It returns redis.Nil when the timeout elapses without a job. BLPOP over several keys
+// pops exactly like BLMPOP LEFT COUNT 1 and is available on every server version.
+func (q *WorkQueue) Dequeue(ctx context.Context, queues []string, timeout time.Duration) (string, string, error) {
+	if q.store == nil {
+		return "", "", ErrUnsupportedStore
+	}
+	if len(queues) == 0 {
+		return "", "", redis.Nil
+	}
+	start := int(atomic.AddUint32(&q.next, 1)-1) % len(queues)
+	keys := make([]string, len(queues))
+	for i := range queues {
+		keys[i] = q.store.Prefix + queues[(start+i)%len(queues)]
+	}
+
+	res, err := q.store.Redis.BLPop(ctx, timeout, keys...).Result()
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimPrefix(res[0], q.store.Prefix), res[1], nil
+}
+
+// Len Retrieve the number of jobs waiting in queue.
+func (q *WorkQueue) Len(ctx context.Context, queue string) (int64, error) {
+	if q.store == nil {
+		return 0, ErrUnsupportedStore
+	}
+
+	return q.store.Redis.LLen(ctx, q.store.Prefix+queue).Result()
+}
+
+// PriorityQueue is a sorted set whose members are dequeued lowest priority first.
+type PriorityQueue struct {
+	store *Redis
+	key   string
+}
+
+// NewPriorityQueue Create a PriorityQueue stored in the sorted set at key.
+// The store must be created by New; with other stores every method returns ErrUnsupportedStore.
+func NewPriorityQueue(store cache.Store, key string) *PriorityQueue {
+	r, _ := store.(*Redis)
+	return &PriorityQueue{store: r, key: key}
+}
+
+// Enqueue Add item with the given priority, updating the priority of an existing item.
+func (q *PriorityQueue) Enqueue(ctx context.Context, item string, priority float64) error {
+	if q.store == nil {
+		return ErrUnsupportedStore
+	}
+
+	return q.store.Redis.ZAdd(ctx, q.store.Prefix+q.key, &redis.Z{Score: priority, Member: item}).Err()
+}
+
+// Dequeue Remove and return the item with the lowest priority, or redis.Nil when empty.
+func (q *PriorityQueue) Dequeue(ctx context.Context) (string, float64, error) {
+	if q.store == nil {
+		return "", 0, ErrUnsupportedStore
+	}
+	res, err := q.store.Redis.ZPopMin(ctx, q.store.Prefix+q.key).Result()
+	if err != nil {
+		return "", 0, err
+	}
+	if len(res) == 0 {
+		return "", 0, redis.Nil
+	}
+	item, _ := res[0].Member.(string)
+
+	return item, res[0].Score, nil
+}
+
+// DequeueBlocking Remove and return the item with the lowest priority, waiting up to timeout
+// for one to arrive. It returns redis.Nil when the timeout elapses.
+func (q *PriorityQueue) DequeueBlocking(ctx context.Context, timeout time.Duration) (string, float64, error) {
+	if q.store == nil {
+		return "", 0, ErrUnsupportedStore
+	}
+	res, err := q.store.Redis.BZPopMin(ctx, timeout, q.store.Prefix+q.key).Result()
+	if err != nil {
+		return "", 0, err
+	}
+	item, _ := res.Member.(string)
+
+	return item, res.Score, nil
+}
+
+// Len Retrieve the number of items in the queue.
+func (q *PriorityQueue) Len(ctx context.Context) (int64, error) {
+	if q.store == nil {
+		return 0, ErrUnsupportedStore
+	}
+
+	return q.store.Redis.ZCard(ctx, q.store.Prefix+q.key).Result()
+}
@@ -0,0 +1,69 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestWorkQueue(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	q := NewWorkQueue(r)
+	ctx := context.Background()
+	_ = q.Enqueue(ctx, "high", "h1")
+	_ = q.Enqueue(ctx, "low", "l1")
+	_ = q.Enqueue(ctx, "low", "l2")
+
+	if n, err := q.Len(ctx, "low"); err != nil || n != 2 {
+		t.Fatalf("Len = %d, %v, want 2", n, err)
+	}
+	seen := map[string]string{}
+	for i := 0; i < 3; i++ {
+		queue, job, err := q.Dequeue(ctx, []string{"high", "low"}, time.Second)
+		if err != nil {
+			t.Fatalf("Dequeue: %v", err)
+		}
+		seen[job] = queue
+	}
+	if seen["h1"] != "high" || seen["l1"] != "low" || seen["l2"] != "low" {
+		t.Fatalf("Dequeue returned %v", seen)
+	}
+	if _, _, err := q.Dequeue(ctx, []string{"high", "low"}, 10*time.Millisecond); err != redis.Nil {
+		t.Fatalf("Dequeue on empty queues = %v, want redis.Nil", err)
+	}
+
+	if err := NewWorkQueue(nil).Enqueue(ctx, "high", "h1"); err != ErrUnsupportedStore {
+		t.Fatalf("Enqueue without a Redis store = %v, want ErrUnsupportedStore", err)
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	q := NewPriorityQueue(r, "jobs")
+	ctx := context.Background()
+	_ = q.Enqueue(ctx, "b", 2)
+	_ = q.Enqueue(ctx, "a", 5)
+	_ = q.Enqueue(ctx, "a", 1)
+
+	if !m.Exists("app:jobs") {
+		t.Fatal("queue was stored without the prefix")
+	}
+	if n, err := q.Len(ctx); err != nil || n != 2 {
+		t.Fatalf("Len = %d, %v, want 2", n, err)
+	}
+	if item, priority, err := q.Dequeue(ctx); err != nil || item != "a" || priority != 1 {
+		t.Fatalf("Dequeue = %q, %v, %v, want a with priority 1", item, priority, err)
+	}
+	if item, _, err := q.DequeueBlocking(ctx, time.Second); err != nil || item != "b" {
+		t.Fatalf("DequeueBlocking = %q, %v, want b", item, err)
+	}
+	if _, _, err := q.Dequeue(ctx); err != redis.Nil {
+		t.Fatalf("Dequeue on an empty queue = %v, want redis.Nil", err)
+	}
+
+	if _, err := NewPriorityQueue(nil, "jobs").Len(ctx); err != ErrUnsupportedStore {
+		t.Fatalf("Len without a Redis store = %v, want ErrUnsupportedStore", err)
+	}
+}
@@ -3,6 +3,7 @@ package redisCache
 import (
 	"context"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -16,6 +17,47 @@ type Config struct {
 	DB       int
 	Password string
 	Context  context.Context
+	// CASRetries is the number of attempts made by CompareAndSwap and
+	// AtomicUpdate when a concurrent write aborts the transaction. Defaults to 3.
+	CASRetries int
+	// NegativeCache remembers missed keys locally for NegativeCacheTTL so
+	// repeated Gets for missing data do not reach Redis. Writes made through
+	// this store clear the entry; writes from elsewhere are seen once it expires.
+	NegativeCache    bool
+	NegativeCacheTTL time.Duration
+	// Version namespaces every key as Prefix+":v"+Version+":"+key when set,
+	// so bumping it invalidates the whole cache without a flush.
+	Version int
+	// EncodingPollInterval is how often EncodingMonitor checks OBJECT
+	// ENCODING. Defaults to one second.
+	EncodingPollInterval time.Duration
+	// InvalidationChannel is the Pub/Sub channel used by Invalidate and
+	// StartInvalidationSubscriber. Defaults to "redisCache:invalidations".
+	InvalidationChannel string
+	// CompressionThreshold is the size in bytes above which string values
+	// are gzip-compressed on write. Defaults to 1024; negative disables it.
+	CompressionThreshold int
+	// MinVersion is the oldest server version, as "major.minor", that New
+	// accepts. Set it when relying on commands such as LMPOP or FUNCTION
+	// that only exist on newer servers.
+	MinVersion string
+	// MaxDeliveryAttempts is how many times AtLeastOnceConsumer delivers a
+	// message before moving it to the dead-letter stream. Defaults to 3.
+	MaxDeliveryAttempts int64
+	// RedeliveryDelay is how long a message AtLeastOnceConsumer failed to
+	// handle stays pending before it is delivered again. Defaults to 5 seconds.
+	RedeliveryDelay time.Duration
+	// OnConsumerError is called by AtLeastOnceConsumer with every message
+	// whose handler failed, before the message is retried.
+	OnConsumerError func(stream string, msg redis.XMessage, err error)
+	// MaxBatchSize is the number of members ZAddBatch sends per ZADD.
+	// Defaults to 1000.
+	MaxBatchSize int
+	// LowTTLThreshold is the remaining TTL below which a TTLAwareStore
+	// reports an item it read. Defaults to 10 seconds.
+	LowTTLThreshold time.Duration
+	// Clock is the time source for local bookkeeping. Defaults to RealClock.
+	Clock Clock
 }
 
 type Redis struct {
@@ -23,6 +65,11 @@ type Redis struct {
 	Prefix string
 	Redis  *redis.Client
 	config Config
+
+	negative      sync.Map
+	negativeMu    sync.Mutex
+	negativeSweep time.Time
+	features      FeatureSet
 }
 
 func New(config ...Config) (cache.Store, error) {
@@ -36,6 +83,33 @@ func New(config ...Config) (cache.Store, error) {
 	if cfg.Port == "" {
 		cfg.Port = "6379"
 	}
+	if cfg.CASRetries <= 0 {
+		cfg.CASRetries = 3
+	}
+	if cfg.EncodingPollInterval <= 0 {
+		cfg.EncodingPollInterval = time.Second
+	}
+	if cfg.InvalidationChannel == "" {
+		cfg.InvalidationChannel = "redisCache:invalidations"
+	}
+	if cfg.CompressionThreshold == 0 {
+		cfg.CompressionThreshold = 1024
+	}
+	if cfg.MaxDeliveryAttempts <= 0 {
+		cfg.MaxDeliveryAttempts = 3
+	}
+	if cfg.RedeliveryDelay <= 0 {
+		cfg.RedeliveryDelay = 5 * time.Second
+	}
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = 1000
+	}
+	if cfg.LowTTLThreshold <= 0 {
+		cfg.LowTTLThreshold = 10 * time.Second
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = RealClock{}
+	}
 	client := redis.NewClient(&redis.Options{
 		Addr:     cfg.Host + ":" + cfg.Port,
 		Password: cfg.Password,
@@ -49,11 +123,30 @@ func New(config ...Config) (cache.Store, error) {
 		return nil, err
 	}
 
-	return &Redis{
+	r := &Redis{
 		ctx:    cfg.Context,
 		Redis:  client,
-		Prefix: cfg.Prefix,
-	}, nil
+		Prefix: versionPrefix(cfg.Prefix, cfg.Version),
+		config: cfg,
+	}
+	r.features = r.detectFeatures(cfg.Context)
+	if cfg.MinVersion != "" {
+		if err := r.checkMinVersion(cfg.MinVersion); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// versionPrefix Build the key prefix for the given namespace version.
+func versionPrefix(prefix string, version int) string {
+	if version == 0 {
+		return prefix
+	}
+
+	return prefix + ":v" + strconv.Itoa(version) + ":"
 }
 
 func (r *Redis) WithContext(ctx context.Context) cache.Store {
@@ -62,9 +155,29 @@ func (r *Redis) WithContext(ctx context.Context) cache.Store {
 	return store
 }
 
+// prefixKeys Apply the store prefix to every key.
+func (r *Redis) prefixKeys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.Prefix + key
+	}
+
+	return prefixed
+}
+
 // Get Retrieve an item from the cache by key.
 func (r *Redis) Get(key string, def interface{}) interface{} {
-	val, err := r.Redis.Get(r.ctx, r.Prefix+key).Result()
+	var val string
+	var err error = redis.Nil
+	if !r.isNegative(key) {
+		val, err = r.Redis.Get(r.ctx, r.Prefix+key).Result()
+		if err == redis.Nil {
+			r.markNegative(key)
+		}
+		if val == nullMarker {
+			err = redis.Nil
+		}
+	}
 	if err != nil {
 		switch s := def.(type) {
 		case func() interface{}:
@@ -74,7 +187,7 @@ func (r *Redis) Get(key string, def interface{}) interface{} {
 		}
 	}
 
-	return val
+	return decodeValue(val)
 }
 
 func (r *Redis) GetBool(key string, def bool) bool {
@@ -130,32 +243,39 @@ func (r *Redis) Has(key string) bool {
 
 // Put Store an item in the cache for a given number of seconds.
 func (r *Redis) Put(key string, value interface{}, seconds time.Duration) error {
-	err := r.Redis.Set(r.ctx, r.Prefix+key, value, seconds).Err()
+	err := r.Redis.Set(r.ctx, r.Prefix+key, r.encodeValue(value), seconds).Err()
 	if err != nil {
 		return err
 	}
+	r.negative.Delete(key)
 
 	return nil
 }
 
 // Pull Retrieve an item from the cache and delete it.
 func (r *Redis) Pull(key string, def interface{}) interface{} {
-	val, err := r.Redis.Get(r.ctx, r.Prefix+key).Result()
-	r.Redis.Del(r.ctx, r.Prefix+key)
+	var val string
+	var err error
+	if r.features.GetDel {
+		val, err = r.Redis.GetDel(r.ctx, r.Prefix+key).Result()
+	} else {
+		val, err = getDelScript.Run(r.ctx, r.Redis, []string{r.Prefix + key}).Text()
+	}
 
-	if err != nil {
+	if err != nil || val == nullMarker {
 		return def
 	}
 
-	return val
+	return decodeValue(val)
 }
 
 // Add Store an item in the cache if the key does not exist.
 func (r *Redis) Add(key string, value interface{}, seconds time.Duration) bool {
-	val, err := r.Redis.SetNX(r.ctx, r.Prefix+key, value, seconds).Result()
+	val, err := r.Redis.SetNX(r.ctx, r.Prefix+key, r.encodeValue(value), seconds).Result()
 	if err != nil {
 		return false
 	}
+	r.negative.Delete(key)
 
 	return val
 }
@@ -194,6 +314,85 @@ func (r *Redis) RememberForever(key string, callback func() interface{}) (interf
 	return val, nil
 }
 
+// SlidingRemember Get an item from the cache resetting its TTL, or execute the given function and store the result.
+func (r *Redis) SlidingRemember(ctx context.Context, key string, ttl time.Duration, fn func() (interface{}, error)) (interface{}, error) {
+	val, err := r.getEx(ctx, key, ttl)
+	if err == nil && val != nullMarker {
+		return decodeValue(val), nil
+	}
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	res, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Redis.Set(ctx, r.Prefix+key, r.encodeValue(res), ttl).Err(); err != nil {
+		return nil, err
+	}
+	r.negative.Delete(key)
+
+	return res, nil
+}
+
+// getEx Read key and reset its TTL, with GET and PEXPIRE in a transaction on servers without GETEX.
+func (r *Redis) getEx(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if r.features.GetEx {
+		return r.Redis.GetEx(ctx, r.Prefix+key, ttl).Result()
+	}
+
+	var get *redis.StringCmd
+	_, err := r.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, r.Prefix+key)
+		if ttl > 0 {
+			pipe.PExpire(ctx, r.Prefix+key, ttl)
+		} else {
+			pipe.Persist(ctx, r.Prefix+key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return "", err
+	}
+
+	return get.Result()
+}
+
+// nullMarker is stored by RememberMiss in place of data that does not exist.
+// Other reads treat it as a missing item.
+const nullMarker = headerMagic + string(kindNull)
+
+// RememberMiss Get an item from the cache, or execute the given function and store the result.
+// When fn returns ErrNotFound a null marker is stored for missTTL instead, and until it
+// expires RememberMiss returns nil without calling fn.
+func (r *Redis) RememberMiss(ctx context.Context, key string, ttl time.Duration, missTTL time.Duration, fn func() (interface{}, error)) (interface{}, error) {
+	val, err := r.Redis.Get(ctx, r.Prefix+key).Result()
+	if err == nil {
+		if val == nullMarker {
+			return nil, nil
+		}
+		return decodeValue(val), nil
+	}
+	if err != redis.Nil {
+		return nil, err
+	}
+
+	res, err := fn()
+	if err == ErrNotFound {
+		return nil, r.Redis.Set(ctx, r.Prefix+key, nullMarker, missTTL).Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Redis.Set(ctx, r.Prefix+key, r.encodeValue(res), ttl).Err(); err != nil {
+		return nil, err
+	}
+	r.negative.Delete(key)
+
+	return res, nil
+}
+
 // Forever Store an item in the cache indefinitely.
 func (r *Redis) Forever(key string, value interface{}) bool {
 	if err := r.Put(key, value, 0); err != nil {
@@ -0,0 +1,236 @@
+package redisCache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+)
+
+// newTestStore Start a miniredis server and connect a store to it.
+func newTestStore(t *testing.T, config ...Config) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+	m := miniredis.RunT(t)
+
+	var cfg Config
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	cfg.Host = m.Host()
+	cfg.Port = m.Port()
+	store, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r := store.(*Redis)
+	t.Cleanup(func() { _ = r.Redis.Close() })
+
+	return r, m
+}
+
+// stubs Records the commands received by a miniredis server and answers
+// the ones it does not implement.
+type stubs struct {
+	mu      sync.Mutex
+	calls   [][]string
+	peers   []*server.Peer
+	stubbed []bool
+	replies map[string]func(c *server.Peer, args []string)
+}
+
+// stubCommands Install a stubs recorder in front of the commands of m.
+func stubCommands(m *miniredis.Miniredis) *stubs {
+	s := &stubs{replies: map[string]func(c *server.Peer, args []string){}}
+	m.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		s.mu.Lock()
+		s.calls = append(s.calls, append([]string{cmd}, args...))
+		reply := s.replies[cmd]
+		s.peers = append(s.peers, c)
+		s.stubbed = append(s.stubbed, reply != nil)
+		s.mu.Unlock()
+		if reply == nil {
+			return false
+		}
+		reply(c, args)
+		return true
+	})
+
+	return s
+}
+
+// on Answer cmd with reply instead of passing it to miniredis.
+func (s *stubs) on(cmd string, reply func(c *server.Peer, args []string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies[strings.ToUpper(cmd)] = reply
+}
+
+// called Return the arguments of every received cmd.
+func (s *stubs) called(cmd string) [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls [][]string
+	for _, call := range s.calls {
+		if call[0] == strings.ToUpper(cmd) {
+			calls = append(calls, call[1:])
+		}
+	}
+
+	return calls
+}
+
+// inMulti Check whether the last transaction command miniredis received from c opened
+// a MULTI, ignoring the command being handled.
+func (s *stubs) inMulti(c *server.Peer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.calls) - 2; i >= 0; i-- {
+		if s.peers[i] != c || s.stubbed[i] {
+			continue
+		}
+		switch s.calls[i][0] {
+		case "MULTI":
+			return true
+		case "EXEC", "DISCARD", "RESET":
+			return false
+		}
+	}
+
+	return false
+}
+
+// lastCall Return the arguments of the last received cmd, failing the test if there was none.
+func (s *stubs) lastCall(t *testing.T, cmd string) []string {
+	t.Helper()
+	calls := s.called(cmd)
+	if len(calls) == 0 {
+		t.Fatalf("%s was not sent", cmd)
+	}
+
+	return calls[len(calls)-1]
+}
+
+// lastPeer Return the connection that sent the last received cmd, failing the test if there was none.
+func (s *stubs) lastPeer(t *testing.T, cmd string) *server.Peer {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.calls) - 1; i >= 0; i-- {
+		if s.calls[i][0] == strings.ToUpper(cmd) {
+			return s.peers[i]
+		}
+	}
+	t.Fatalf("%s was not sent", cmd)
+
+	return nil
+}
+
+// equalArgs Fail the test unless got matches want.
+func equalArgs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("args = %q, want %q", got, want)
+	}
+}
+
+func TestPutGet(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+
+	if err := r.Put("name", "redis", 0); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := r.GetString("name", ""); got != "redis" {
+		t.Fatalf("GetString = %q, want %q", got, "redis")
+	}
+	if got := r.Get("missing", "def"); got != "def" {
+		t.Fatalf("Get missing = %v, want def", got)
+	}
+}
+
+func TestSlidingRemember(t *testing.T) {
+	r, m := newTestStore(t)
+	ctx := context.Background()
+	calls := 0
+	fn := func() (interface{}, error) {
+		calls++
+		return "computed", nil
+	}
+
+	val, err := r.SlidingRemember(ctx, "k", time.Minute, fn)
+	if err != nil || val != "computed" || calls != 1 {
+		t.Fatalf("SlidingRemember on miss = %v, %v after %d calls", val, err, calls)
+	}
+	m.FastForward(50 * time.Second)
+	val, err = r.SlidingRemember(ctx, "k", time.Minute, fn)
+	if err != nil || val != "computed" || calls != 1 {
+		t.Fatalf("SlidingRemember on hit = %v, %v after %d calls", val, err, calls)
+	}
+	if ttl := m.TTL("k"); ttl != time.Minute {
+		t.Fatalf("TTL = %v, want it slid back to 1m", ttl)
+	}
+}
+
+func TestRememberMiss(t *testing.T) {
+	r, m := newTestStore(t)
+	ctx := context.Background()
+	calls := 0
+	missing := func() (interface{}, error) {
+		calls++
+		return nil, ErrNotFound
+	}
+
+	for i := 0; i < 2; i++ {
+		val, err := r.RememberMiss(ctx, "user:1", time.Hour, time.Minute, missing)
+		if err != nil || val != nil {
+			t.Fatalf("RememberMiss = %v, %v, want nil", val, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("fn called %d times, want 1", calls)
+	}
+	if ttl := m.TTL("user:1"); ttl != time.Minute {
+		t.Fatalf("miss TTL = %v, want 1m", ttl)
+	}
+
+	m.FastForward(time.Minute)
+	val, err := r.RememberMiss(ctx, "user:1", time.Hour, time.Minute, func() (interface{}, error) { return "found", nil })
+	if err != nil || val != "found" {
+		t.Fatalf("RememberMiss after the miss expired = %v, %v", val, err)
+	}
+	if got := r.GetString("user:1", ""); got != "found" {
+		t.Fatalf("stored value = %q, want found", got)
+	}
+}
+
+func TestSlidingRememberWithoutGetEx(t *testing.T) {
+	r, m := newTestStore(t)
+	r.features.GetEx = false
+	s := stubCommands(m)
+	ctx := context.Background()
+	m.Set("k", "cached")
+	m.SetTTL("k", 10*time.Second)
+
+	val, err := r.SlidingRemember(ctx, "k", time.Minute, func() (interface{}, error) {
+		return "computed", nil
+	})
+	if err != nil || val != "cached" {
+		t.Fatalf("SlidingRemember = %v, %v, want cached", val, err)
+	}
+	if ttl := m.TTL("k"); ttl != time.Minute {
+		t.Fatalf("TTL = %v, want it slid back to 1m", ttl)
+	}
+	if calls := s.called("GETEX"); len(calls) != 0 {
+		t.Fatalf("GETEX sent %d times without server support", len(calls))
+	}
+
+	val, err = r.SlidingRemember(ctx, "missing", time.Minute, func() (interface{}, error) {
+		return "computed", nil
+	})
+	if err != nil || val != "computed" {
+		t.Fatalf("SlidingRemember on miss = %v, %v", val, err)
+	}
+}
@@ -0,0 +1,77 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ReplicationInfo is the parsed replication section of INFO.
+type ReplicationInfo struct {
+	Role             string
+	ConnectedSlaves  int
+	MasterReplOffset int64
+	Slaves           []SlaveInfo
+}
+
+// SlaveInfo describes a replica connected to a master.
+type SlaveInfo struct {
+	IP     string
+	Port   int
+	State  string
+	Offset int64
+	Lag    int64
+}
+
+// ReplicationInfo Retrieve the role of the server and, on a master, the offset and lag of its replicas.
+func (r *Redis) ReplicationInfo(ctx context.Context) (*ReplicationInfo, error) {
+	res, err := r.Redis.Info(ctx, "replication").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReplicationInfo(res), nil
+}
+
+func parseReplicationInfo(info string) *ReplicationInfo {
+	repl := &ReplicationInfo{}
+	for _, line := range strings.Split(info, "\n") {
+		field, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch {
+		case field == "role":
+			repl.Role = value
+		case field == "connected_slaves":
+			repl.ConnectedSlaves, _ = strconv.Atoi(value)
+		case field == "master_repl_offset":
+			repl.MasterReplOffset, _ = strconv.ParseInt(value, 10, 64)
+		case strings.HasPrefix(field, "slave") && strings.Contains(value, "="):
+			repl.Slaves = append(repl.Slaves, parseSlaveInfo(value))
+		}
+	}
+
+	return repl
+}
+
+func parseSlaveInfo(value string) SlaveInfo {
+	var slave SlaveInfo
+	for _, part := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "ip":
+			slave.IP = v
+		case "port":
+			slave.Port, _ = strconv.Atoi(v)
+		case "state":
+			slave.State = v
+		case "offset":
+			slave.Offset, _ = strconv.ParseInt(v, 10, 64)
+		case "lag":
+			slave.Lag, _ = strconv.ParseInt(v, 10, 64)
+		}
+	}
+
+	return slave
+}
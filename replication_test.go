@@ -0,0 +1,36 @@
+package redisCache
+
+import (
+	"testing"
+)
+
+func TestParseReplicationInfo(t *testing.T) {
+	info := "# Replication\r\n" +
+		"role:master\r\n" +
+		"connected_slaves:2\r\n" +
+		"slave0:ip=10.0.0.2,port=6380,state=online,offset=1200,lag=0\r\n" +
+		"slave1:ip=10.0.0.3,port=6381,state=wait_bgsave,offset=0,lag=3\r\n" +
+		"master_failover_state:no-failover\r\n" +
+		"master_replid:8c5b1a3e2f\r\n" +
+		"master_repl_offset:1234\r\n" +
+		"slave_read_repl_offset:1234\r\n"
+
+	repl := parseReplicationInfo(info)
+	if repl.Role != "master" || repl.ConnectedSlaves != 2 || repl.MasterReplOffset != 1234 {
+		t.Fatalf("parseReplicationInfo = %+v", repl)
+	}
+	if len(repl.Slaves) != 2 {
+		t.Fatalf("slaves = %+v, want 2", repl.Slaves)
+	}
+	want := SlaveInfo{IP: "10.0.0.3", Port: 6381, State: "wait_bgsave", Offset: 0, Lag: 3}
+	if repl.Slaves[1] != want {
+		t.Fatalf("slave1 = %+v, want %+v", repl.Slaves[1], want)
+	}
+}
+
+func TestParseReplicationInfoReplica(t *testing.T) {
+	repl := parseReplicationInfo("role:slave\r\nmaster_host:10.0.0.1\r\nslave_repl_offset:99\r\nconnected_slaves:0\r\n")
+	if repl.Role != "slave" || len(repl.Slaves) != 0 {
+		t.Fatalf("parseReplicationInfo = %+v", repl)
+	}
+}
@@ -0,0 +1,20 @@
+package redisCache
+
+import (
+	"context"
+)
+
+// ScriptExists Check which of the given SHA1 digests are in the script cache.
+// The result is parallel to hashes.
+func (r *Redis) ScriptExists(ctx context.Context, hashes ...string) ([]bool, error) {
+	return r.Redis.ScriptExists(ctx, hashes...).Result()
+}
+
+// ScriptFlush Empty the Lua script cache, asynchronously when async is true (Redis 6.2+).
+func (r *Redis) ScriptFlush(ctx context.Context, async ...bool) error {
+	if len(async) > 0 && async[0] {
+		return r.Redis.Do(ctx, "script", "flush", "async").Err()
+	}
+
+	return r.Redis.ScriptFlush(ctx).Err()
+}
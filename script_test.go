@@ -0,0 +1,41 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestScriptExists(t *testing.T) {
+	r, _ := newTestStore(t)
+	ctx := context.Background()
+	sha, err := r.Redis.ScriptLoad(ctx, "return 1").Result()
+	if err != nil {
+		t.Fatalf("ScriptLoad: %v", err)
+	}
+
+	exists, err := r.ScriptExists(ctx, sha, "0000000000000000000000000000000000000000")
+	if err != nil || len(exists) != 2 || !exists[0] || exists[1] {
+		t.Fatalf("ScriptExists = %v, %v, want [true false]", exists, err)
+	}
+}
+
+func TestScriptFlush(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	ctx := context.Background()
+
+	for _, async := range []bool{false, true} {
+		sha, err := r.Redis.ScriptLoad(ctx, "return 1").Result()
+		if err != nil {
+			t.Fatalf("ScriptLoad: %v", err)
+		}
+		if err := r.ScriptFlush(ctx, async); err != nil {
+			t.Fatalf("ScriptFlush(%v): %v", async, err)
+		}
+		if exists, err := r.ScriptExists(ctx, sha); err != nil || exists[0] {
+			t.Fatalf("ScriptExists after ScriptFlush(%v) = %v, %v", async, exists, err)
+		}
+	}
+	flushes := s.called("SCRIPT")
+	equalArgs(t, flushes[len(flushes)-2], "flush", "async")
+}
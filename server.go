@@ -0,0 +1,289 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ConfigRewrite Persist the in-memory server configuration to its redis.conf file.
+func (r *Redis) ConfigRewrite(ctx context.Context) error {
+	err := r.Redis.ConfigRewrite(ctx).Err()
+	if err != nil && strings.Contains(err.Error(), "without a config file") {
+		return ErrNoConfigFile
+	}
+
+	return err
+}
+
+// BGSave Start a background save of the dataset to disk.
+func (r *Redis) BGSave(ctx context.Context) error {
+	err := r.Redis.BgSave(ctx).Err()
+	if err != nil && strings.Contains(err.Error(), "already in progress") {
+		return ErrSaveInProgress
+	}
+
+	return err
+}
+
+// LastSave Retrieve the time of the last successful save to disk.
+func (r *Redis) LastSave(ctx context.Context) (time.Time, error) {
+	ts, err := r.Redis.LastSave(ctx).Result()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(ts, 0), nil
+}
+
+// BGRewriteAOF Start a background rewrite of the append-only file.
+func (r *Redis) BGRewriteAOF(ctx context.Context) error {
+	res, err := r.Redis.ConfigGet(ctx, "appendonly").Result()
+	if err != nil {
+		return err
+	}
+	if len(res) < 2 || res[1] != "yes" {
+		return ErrAOFDisabled
+	}
+
+	return r.Redis.BgRewriteAOF(ctx).Err()
+}
+
+// Failover Start a coordinated failover from the connected master to one of its replicas.
+// FAILOVER must be sent to the master: the replica taking over has to be connected and
+// in sync, and is the one designated as successor by the server. Sending it to a replica
+// returns ErrNotMaster.
+func (r *Redis) Failover(ctx context.Context) error {
+	err := r.Redis.Do(ctx, "failover").Err()
+	if err != nil && strings.Contains(err.Error(), "is a replica") {
+		return ErrNotMaster
+	}
+
+	return err
+}
+
+// ReplicaOf Turn the server into a replica of the master at host:port.
+// The server discards its current dataset and resyncs from the new master, so calling
+// this against the wrong node loses data.
+func (r *Redis) ReplicaOf(ctx context.Context, host string, port int) error {
+	if err := validateAddress(host, port); err != nil {
+		return err
+	}
+
+	return r.Redis.SlaveOf(ctx, host, strconv.Itoa(port)).Err()
+}
+
+// ReplicaOfNoOne Stop replication and promote the server to a master.
+func (r *Redis) ReplicaOfNoOne(ctx context.Context) error {
+	return r.Redis.SlaveOf(ctx, "NO", "ONE").Err()
+}
+
+func validateAddress(host string, port int) error {
+	if strings.TrimSpace(host) == "" || port <= 0 || port > 65535 {
+		return ErrInvalidAddress
+	}
+
+	return nil
+}
+
+// ResetConn Reset a dedicated connection obtained from Redis.Conn, e.g. after an aborted
+// MULTI, so it can be safely returned to the pool. RESET also logs the connection out and
+// selects database 0, so the configured credentials and database are restored afterwards.
+// Pooled commands and pipelines need no reset: the client discards connections that fail
+// mid-command, and CompareAndSwap and AtomicUpdate reset their connection themselves after
+// a failed transaction. Requires Redis 6.2 or later.
+func (r *Redis) ResetConn(ctx context.Context, conn *redis.Conn) error {
+	return r.resetConn(ctx, conn)
+}
+
+// processor is implemented by the connection types that can run a single command.
+type processor interface {
+	Process(ctx context.Context, cmd redis.Cmder) error
+}
+
+// resetConn Send RESET on the connection of p and restore the configured credentials and database.
+func (r *Redis) resetConn(ctx context.Context, p processor) error {
+	if err := p.Process(ctx, redis.NewStatusCmd(ctx, "reset")); err != nil {
+		return err
+	}
+	if r.config.Password != "" {
+		if err := p.Process(ctx, redis.NewStatusCmd(ctx, "auth", r.config.Password)); err != nil {
+			return err
+		}
+	}
+	if r.config.DB != 0 {
+		return p.Process(ctx, redis.NewStatusCmd(ctx, "select", r.config.DB))
+	}
+
+	return nil
+}
+
+// Hello Negotiate the protocol version with the server and retrieve its capabilities,
+// such as "server", "version" and "proto". The underlying client speaks RESP2, so any other
+// version returns ErrUnsupportedProtocol without contacting the server: switching a pooled
+// connection to RESP3 would leave it unreadable.
+func (r *Redis) Hello(ctx context.Context, ver int, args ...interface{}) (map[string]interface{}, error) {
+	if ver != 2 {
+		return nil, ErrUnsupportedProtocol
+	}
+	res, err := r.Redis.Do(ctx, append([]interface{}{"hello", ver}, args...)...).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	info := make(map[string]interface{}, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		if field, ok := res[i].(string); ok {
+			info[field] = res[i+1]
+		}
+	}
+
+	return info, nil
+}
+
+// CommandDocs Retrieve the documentation of the given commands, or of every command
+// when none are given. Servers older than Redis 7.0 yield an empty map.
+func (r *Redis) CommandDocs(ctx context.Context, commands ...string) (map[string]interface{}, error) {
+	args := []interface{}{"command", "docs"}
+	for _, c := range commands {
+		args = append(args, c)
+	}
+	res, err := r.Redis.Do(ctx, args...).Slice()
+	if err != nil {
+		if isUnknownCommand(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+
+	docs := make(map[string]interface{}, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		name, ok := res[i].(string)
+		if !ok {
+			continue
+		}
+		docs[name] = pairsToMap(res[i+1])
+	}
+
+	return docs, nil
+}
+
+// pairsToMap Convert a flat RESP2 array of field/value pairs into a map, recursively.
+// Other arrays have their elements converted; remaining values are returned unchanged.
+func pairsToMap(v interface{}) interface{} {
+	items, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	if len(items)%2 == 0 {
+		m := make(map[string]interface{}, len(items)/2)
+		for i := 0; i < len(items); i += 2 {
+			field, ok := items[i].(string)
+			if !ok {
+				m = nil
+				break
+			}
+			m[field] = pairsToMap(items[i+1])
+		}
+		if m != nil {
+			return m
+		}
+	}
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = pairsToMap(item)
+	}
+
+	return list
+}
+
+// flatPairs Convert a flat RESP2 array of field/value pairs into a map without touching the values.
+func flatPairs(v interface{}) map[string]interface{} {
+	items, _ := v.([]interface{})
+	m := make(map[string]interface{}, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		if field, ok := items[i].(string); ok {
+			m[field] = items[i+1]
+		}
+	}
+
+	return m
+}
+
+// isUnknownCommand Check whether err reports a command or subcommand the server does not know.
+func isUnknownCommand(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unknown command") || strings.Contains(msg, "unknown subcommand")
+}
+
+// MemoryDoctor Retrieve the human-readable memory diagnostic report of the server.
+func (r *Redis) MemoryDoctor(ctx context.Context) (string, error) {
+	return r.Redis.Do(ctx, "memory", "doctor").Text()
+}
+
+// MemoryStats Retrieve the memory usage statistics of the server, such as
+// "peak.allocated", "total.allocated" and "fragmentation". Integer fields are int64,
+// fractional fields float64 and nested sections maps.
+func (r *Redis) MemoryStats(ctx context.Context) (map[string]interface{}, error) {
+	res, err := r.Redis.Do(ctx, "memory", "stats").Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]interface{}, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		field, ok := res[i].(string)
+		if !ok {
+			continue
+		}
+		switch v := res[i+1].(type) {
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				stats[field] = f
+			} else {
+				stats[field] = v
+			}
+		default:
+			stats[field] = pairsToMap(v)
+		}
+	}
+
+	return stats, nil
+}
+
+// CommandGetKeys Retrieve the keys a command would access when called with the given arguments.
+func (r *Redis) CommandGetKeys(ctx context.Context, args ...interface{}) ([]string, error) {
+	return r.Redis.Do(ctx, append([]interface{}{"command", "getkeys"}, args...)...).StringSlice()
+}
+
+// WaitForWrite Block until numReplicas replicas acknowledged the last write, or until the
+// deadline of ctx, and return how many did. Without a deadline it waits indefinitely.
+// WAIT only covers writes made on the pooled connection it runs on, and whether fewer
+// replicas than requested is fatal is left to the caller.
+func (r *Redis) WaitForWrite(ctx context.Context, numReplicas int) (int, error) {
+	n, err := r.Redis.Wait(ctx, numReplicas, waitTimeout(ctx)).Result()
+
+	return int(n), err
+}
+
+// waitTimeout Return the WAIT timeout for the deadline of ctx, or 0 to wait indefinitely.
+// A deadline that has passed is clamped to 1ms, since 0 would block forever.
+func waitTimeout(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	if timeout := time.Until(deadline); timeout > time.Millisecond {
+		return timeout
+	}
+
+	return time.Millisecond
+}
+
+// ObjectHelp Retrieve the help text of the OBJECT command, one line per element.
+func (r *Redis) ObjectHelp(ctx context.Context) ([]string, error) {
+	return r.Redis.Do(ctx, "object", "help").StringSlice()
+}
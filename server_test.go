@@ -0,0 +1,370 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestConfigRewrite(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	ctx := context.Background()
+
+	s.on("CONFIG", func(c *server.Peer, args []string) { c.WriteOK() })
+	if err := r.ConfigRewrite(ctx); err != nil {
+		t.Fatalf("ConfigRewrite: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "CONFIG"), "rewrite")
+	s.on("CONFIG", func(c *server.Peer, args []string) {
+		c.WriteError("ERR The server is running without a config file")
+	})
+	if err := r.ConfigRewrite(ctx); err != ErrNoConfigFile {
+		t.Fatalf("ConfigRewrite = %v, want ErrNoConfigFile", err)
+	}
+}
+
+func TestBGSave(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	ctx := context.Background()
+
+	s.on("BGSAVE", func(c *server.Peer, args []string) { c.WriteInline("Background saving started") })
+	if err := r.BGSave(ctx); err != nil {
+		t.Fatalf("BGSave: %v", err)
+	}
+	s.on("BGSAVE", func(c *server.Peer, args []string) { c.WriteError("ERR Background save already in progress") })
+	if err := r.BGSave(ctx); err != ErrSaveInProgress {
+		t.Fatalf("BGSave = %v, want ErrSaveInProgress", err)
+	}
+}
+
+func TestFailover(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	ctx := context.Background()
+
+	s.on("FAILOVER", func(c *server.Peer, args []string) { c.WriteOK() })
+	if err := r.Failover(ctx); err != nil {
+		t.Fatalf("Failover: %v", err)
+	}
+	s.on("FAILOVER", func(c *server.Peer, args []string) {
+		c.WriteError("ERR FAILOVER is not valid when server is a replica.")
+	})
+	if err := r.Failover(ctx); err != ErrNotMaster {
+		t.Fatalf("Failover on a replica = %v, want ErrNotMaster", err)
+	}
+}
+
+func TestLastSave(t *testing.T) {
+	r, m := newTestStore(t)
+	stubCommands(m).on("LASTSAVE", func(c *server.Peer, args []string) { c.WriteInt(1700000000) })
+
+	ts, err := r.LastSave(context.Background())
+	if err != nil {
+		t.Fatalf("LastSave: %v", err)
+	}
+	if !ts.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("LastSave = %v", ts)
+	}
+}
+
+func TestBGRewriteAOF(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	ctx := context.Background()
+	appendonly := "no"
+	s.on("CONFIG", func(c *server.Peer, args []string) { c.WriteStrings([]string{"appendonly", appendonly}) })
+	s.on("BGREWRITEAOF", func(c *server.Peer, args []string) {
+		c.WriteInline("Background append only file rewriting started")
+	})
+
+	if err := r.BGRewriteAOF(ctx); err != ErrAOFDisabled {
+		t.Fatalf("BGRewriteAOF = %v, want ErrAOFDisabled", err)
+	}
+	if len(s.called("BGREWRITEAOF")) != 0 {
+		t.Fatal("BGREWRITEAOF sent with AOF disabled")
+	}
+
+	appendonly = "yes"
+	if err := r.BGRewriteAOF(ctx); err != nil {
+		t.Fatalf("BGRewriteAOF: %v", err)
+	}
+	if len(s.called("BGREWRITEAOF")) != 1 {
+		t.Fatal("BGREWRITEAOF not sent")
+	}
+}
+
+func TestReplicaOf(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("SLAVEOF", func(c *server.Peer, args []string) { c.WriteOK() })
+	ctx := context.Background()
+
+	for _, addr := range []struct {
+		host string
+		port int
+	}{{"", 6379}, {"10.0.0.2", 0}, {"10.0.0.2", 70000}} {
+		if err := r.ReplicaOf(ctx, addr.host, addr.port); err != ErrInvalidAddress {
+			t.Fatalf("ReplicaOf(%q, %d) = %v, want ErrInvalidAddress", addr.host, addr.port, err)
+		}
+	}
+	if len(s.called("SLAVEOF")) != 0 {
+		t.Fatal("SLAVEOF sent for an invalid address")
+	}
+
+	if err := r.ReplicaOf(ctx, "10.0.0.2", 6380); err != nil {
+		t.Fatalf("ReplicaOf: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "SLAVEOF"), "10.0.0.2", "6380")
+	if err := r.ReplicaOfNoOne(ctx); err != nil {
+		t.Fatalf("ReplicaOfNoOne: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "SLAVEOF"), "NO", "ONE")
+}
+
+// stubReset Emulate RESET on m, which does not implement it, by discarding an open MULTI.
+func stubReset(m *miniredis.Miniredis, s *stubs) {
+	s.on("RESET", func(c *server.Peer, args []string) {
+		if s.inMulti(c) {
+			m.Server().Dispatch(c, []string{"DISCARD"})
+			return
+		}
+		c.WriteInline("RESET")
+	})
+}
+
+func TestCompareAndSwapResetsAfterFailedTransaction(t *testing.T) {
+	r, m := newTestStore(t, Config{DB: 2})
+	s := stubCommands(m)
+	stubReset(m, s)
+	ctx := context.Background()
+	r.Redis.Set(ctx, "k", "old", 0)
+
+	s.on("EXEC", func(c *server.Peer, args []string) { c.WriteError("EXECABORT Transaction discarded") })
+	if _, err := r.CompareAndSwap(ctx, "k", "old", "new", 0); err == nil {
+		t.Fatal("CompareAndSwap succeeded with EXEC failing")
+	}
+	if len(s.called("RESET")) != 1 {
+		t.Fatal("connection was not reset after the failed transaction")
+	}
+	equalArgs(t, s.lastCall(t, "SELECT"), "2")
+	s.on("EXEC", nil)
+	if got := r.GetString("k", ""); got != "old" {
+		t.Fatalf("Get after reset = %q, want old", got)
+	}
+}
+
+func TestResetConnClearsMulti(t *testing.T) {
+	r, m := newTestStore(t, Config{DB: 2})
+	s := stubCommands(m)
+	stubReset(m, s)
+	ctx := context.Background()
+	conn := r.Redis.Conn(ctx)
+	defer conn.Close()
+
+	if err := conn.Process(ctx, redis.NewStatusCmd(ctx, "multi")); err != nil {
+		t.Fatalf("MULTI: %v", err)
+	}
+	if err := conn.Set(ctx, "k", "queued", 0).Err(); err != nil {
+		t.Fatalf("SET inside MULTI: %v", err)
+	}
+	if err := r.ResetConn(ctx, conn); err != nil {
+		t.Fatalf("ResetConn: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "SELECT"), "2")
+	if _, err := conn.Get(ctx, "k").Result(); err != redis.Nil {
+		t.Fatalf("Get after ResetConn = %v, want redis.Nil outside MULTI", err)
+	}
+	if m.DB(2).Exists("k") {
+		t.Fatal("the queued SET was executed")
+	}
+}
+
+func TestHello(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("HELLO", func(c *server.Peer, args []string) {
+		c.WriteLen(14)
+		c.WriteBulk("server")
+		c.WriteBulk("redis")
+		c.WriteBulk("version")
+		c.WriteBulk("7.2.4")
+		c.WriteBulk("proto")
+		c.WriteInt(2)
+		c.WriteBulk("id")
+		c.WriteInt(7)
+		c.WriteBulk("mode")
+		c.WriteBulk("standalone")
+		c.WriteBulk("role")
+		c.WriteBulk("master")
+		c.WriteBulk("modules")
+		c.WriteLen(0)
+	})
+	ctx := context.Background()
+
+	info, err := r.Hello(ctx, 2)
+	if err != nil {
+		t.Fatalf("Hello: %v", err)
+	}
+	if info["server"] != "redis" || info["version"] != "7.2.4" || info["proto"] != int64(2) {
+		t.Fatalf("Hello = %v", info)
+	}
+
+	if _, err := r.Hello(ctx, 3); err != ErrUnsupportedProtocol {
+		t.Fatalf("Hello(3) = %v, want ErrUnsupportedProtocol", err)
+	}
+	if len(s.called("HELLO")) != 1 {
+		t.Fatal("HELLO 3 was sent to the server")
+	}
+}
+
+func TestCommandDocs(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("COMMAND", func(c *server.Peer, args []string) {
+		c.WriteLen(2)
+		c.WriteBulk("get")
+		c.WriteLen(6)
+		c.WriteBulk("summary")
+		c.WriteBulk("Returns the string value of a key.")
+		c.WriteBulk("since")
+		c.WriteBulk("1.0.0")
+		c.WriteBulk("arguments")
+		c.WriteLen(1)
+		c.WriteLen(4)
+		c.WriteBulk("name")
+		c.WriteBulk("key")
+		c.WriteBulk("type")
+		c.WriteBulk("key")
+	})
+	ctx := context.Background()
+
+	docs, err := r.CommandDocs(ctx, "get")
+	if err != nil {
+		t.Fatalf("CommandDocs: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "COMMAND"), "docs", "get")
+	get, ok := docs["get"].(map[string]interface{})
+	if !ok || get["since"] != "1.0.0" {
+		t.Fatalf("CommandDocs = %v", docs)
+	}
+	args, ok := get["arguments"].([]interface{})
+	if !ok || len(args) != 1 || args[0].(map[string]interface{})["name"] != "key" {
+		t.Fatalf("arguments = %v", get["arguments"])
+	}
+
+	s.on("COMMAND", func(c *server.Peer, args []string) { c.WriteError("ERR unknown subcommand 'docs'") })
+	docs, err = r.CommandDocs(ctx)
+	if err != nil || len(docs) != 0 {
+		t.Fatalf("CommandDocs on an old server = %v, %v, want an empty map", docs, err)
+	}
+}
+
+func TestMemoryDoctor(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("MEMORY", func(c *server.Peer, args []string) { c.WriteBulk("Hi Sam, I can't find any memory issue in your instance.") })
+
+	report, err := r.MemoryDoctor(context.Background())
+	if err != nil || report == "" {
+		t.Fatalf("MemoryDoctor = %q, %v", report, err)
+	}
+	equalArgs(t, s.lastCall(t, "MEMORY"), "doctor")
+}
+
+func TestMemoryStats(t *testing.T) {
+	r, m := newTestStore(t)
+	stubCommands(m).on("MEMORY", func(c *server.Peer, args []string) {
+		c.WriteLen(8)
+		c.WriteBulk("peak.allocated")
+		c.WriteInt(1048576)
+		c.WriteBulk("fragmentation")
+		c.WriteBulk("1.25")
+		c.WriteBulk("db.0")
+		c.WriteLen(4)
+		c.WriteBulk("overhead.hashtable.main")
+		c.WriteInt(72)
+		c.WriteBulk("overhead.hashtable.expires")
+		c.WriteInt(0)
+		c.WriteBulk("allocator")
+		c.WriteBulk("jemalloc-5.3.0")
+	})
+
+	stats, err := r.MemoryStats(context.Background())
+	if err != nil {
+		t.Fatalf("MemoryStats: %v", err)
+	}
+	if stats["peak.allocated"] != int64(1048576) || stats["fragmentation"] != 1.25 || stats["allocator"] != "jemalloc-5.3.0" {
+		t.Fatalf("MemoryStats = %v", stats)
+	}
+	db, ok := stats["db.0"].(map[string]interface{})
+	if !ok || db["overhead.hashtable.main"] != int64(72) {
+		t.Fatalf("db.0 = %v", stats["db.0"])
+	}
+}
+
+func TestCommandGetKeys(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("COMMAND", func(c *server.Peer, args []string) { c.WriteStrings([]string{"a", "b"}) })
+
+	keys, err := r.CommandGetKeys(context.Background(), "mset", "a", "1", "b", "2")
+	if err != nil || len(keys) != 2 || keys[1] != "b" {
+		t.Fatalf("CommandGetKeys = %v, %v", keys, err)
+	}
+	equalArgs(t, s.lastCall(t, "COMMAND"), "getkeys", "mset", "a", "1", "b", "2")
+}
+
+func TestObjectHelp(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("OBJECT", func(c *server.Peer, args []string) {
+		c.WriteStrings([]string{"OBJECT <subcommand> [<arg> [value] [opt] ...]. Subcommands are:", "ENCODING <key>"})
+	})
+
+	lines, err := r.ObjectHelp(context.Background())
+	if err != nil || len(lines) != 2 || lines[1] != "ENCODING <key>" {
+		t.Fatalf("ObjectHelp = %q, %v", lines, err)
+	}
+	equalArgs(t, s.lastCall(t, "OBJECT"), "help")
+}
+
+func TestWaitForWrite(t *testing.T) {
+	r, m := newTestStore(t)
+	s := stubCommands(m)
+	s.on("WAIT", func(c *server.Peer, args []string) { c.WriteInt(2) })
+
+	n, err := r.WaitForWrite(context.Background(), 3)
+	if err != nil || n != 2 {
+		t.Fatalf("WaitForWrite = %d, %v, want 2, nil", n, err)
+	}
+	equalArgs(t, s.lastCall(t, "WAIT"), "3", "0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := r.WaitForWrite(ctx, 1); err != nil {
+		t.Fatalf("WaitForWrite: %v", err)
+	}
+	args := s.lastCall(t, "WAIT")
+	ms, _ := strconv.Atoi(args[1])
+	if args[0] != "1" || ms <= 4000 || ms > 5000 {
+		t.Fatalf("WAIT args = %v, want 1 and a timeout of about 5000ms", args)
+	}
+}
+
+func TestWaitTimeoutClampsPastDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if got := waitTimeout(ctx); got != time.Millisecond {
+		t.Fatalf("waitTimeout = %v, want 1ms", got)
+	}
+	if got := waitTimeout(context.Background()); got != 0 {
+		t.Fatalf("waitTimeout without deadline = %v, want 0", got)
+	}
+}
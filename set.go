@@ -0,0 +1,54 @@
+package redisCache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SInterCard Count the members of the intersection of the sets at keys, stopping at limit.
+// A zero limit counts the whole intersection. Servers older than Redis 7.0 compute the
+// intersection in a script and count it.
+func (r *Redis) SInterCard(ctx context.Context, limit int64, keys ...string) (int64, error) {
+	return r.Redis.Do(ctx, r.sinterCardArgs(limit, keys)...).Int64()
+}
+
+// SInterCardPipeline Queue SINTERCARD on pipe, so the intersection size can be checked in the
+// same pipeline or MULTI/EXEC transaction as other commands. The result is available once
+// the pipeline has been executed.
+func (r *Redis) SInterCardPipeline(pipe redis.Pipeliner, limit int64, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(r.ctx, r.sinterCardArgs(limit, keys)...)
+	_ = pipe.Process(r.ctx, cmd)
+
+	return cmd
+}
+
+// sinterCardScript counts an intersection on servers without SINTERCARD.
+const sinterCardScript = `
+local n = #redis.call('SINTER', unpack(KEYS))
+local limit = tonumber(ARGV[1])
+if limit > 0 and n > limit then
+	return limit
+end
+return n
+`
+
+func (r *Redis) sinterCardArgs(limit int64, keys []string) []interface{} {
+	if !r.features.SInterCard {
+		args := []interface{}{"eval", sinterCardScript, len(keys)}
+		for _, key := range keys {
+			args = append(args, r.Prefix+key)
+		}
+		return append(args, limit)
+	}
+
+	args := []interface{}{"sintercard", len(keys)}
+	for _, key := range keys {
+		args = append(args, r.Prefix+key)
+	}
+	if limit > 0 {
+		args = append(args, "limit", limit)
+	}
+
+	return args
+}
@@ -0,0 +1,59 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestSInterCard(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	_, _ = m.SetAdd("app:a", "1", "2", "3", "4")
+	_, _ = m.SetAdd("app:b", "2", "3", "4", "5")
+
+	if n, err := r.SInterCard(ctx, 0, "a", "b"); err != nil || n != 3 {
+		t.Fatalf("SInterCard = %d, %v, want 3", n, err)
+	}
+	if n, err := r.SInterCard(ctx, 2, "a", "b"); err != nil || n != 2 {
+		t.Fatalf("SInterCard with limit = %d, %v, want 2", n, err)
+	}
+
+	var card *redis.IntCmd
+	_, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		card = r.SInterCardPipeline(pipe, 0, "a", "b")
+		return nil
+	})
+	if err != nil || card.Val() != 3 {
+		t.Fatalf("SInterCardPipeline = %d, %v, want 3", card.Val(), err)
+	}
+}
+
+func TestSInterCardFallback(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	r.features.SInterCard = false
+	s := stubCommands(m)
+	ctx := context.Background()
+	_, _ = m.SetAdd("app:a", "1", "2", "3", "4")
+	_, _ = m.SetAdd("app:b", "2", "3", "4", "5")
+
+	if n, err := r.SInterCard(ctx, 0, "a", "b"); err != nil || n != 3 {
+		t.Fatalf("SInterCard = %d, %v, want 3", n, err)
+	}
+	if n, err := r.SInterCard(ctx, 2, "a", "b"); err != nil || n != 2 {
+		t.Fatalf("SInterCard with limit = %d, %v, want 2", n, err)
+	}
+
+	var card *redis.IntCmd
+	_, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		card = r.SInterCardPipeline(pipe, 0, "a", "b")
+		return nil
+	})
+	if err != nil || card.Val() != 3 {
+		t.Fatalf("SInterCardPipeline = %d, %v, want 3", card.Val(), err)
+	}
+	if calls := s.called("SINTERCARD"); len(calls) != 0 {
+		t.Fatalf("SINTERCARD sent %d times without server support", len(calls))
+	}
+}
@@ -0,0 +1,220 @@
+package redisCache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// XAutoClaim Transfer ownership of pending messages idle for at least args.MinIdle to args.Consumer.
+// The reply is decoded here rather than by the client, which rejects the extra list of
+// deleted IDs returned by Redis 7.0; messages deleted from the stream are left out.
+func (r *Redis) XAutoClaim(ctx context.Context, args *redis.XAutoClaimArgs) (*redis.XAutoClaimCmd, error) {
+	cmdArgs := []interface{}{"xautoclaim", r.Prefix + args.Stream, args.Group, args.Consumer,
+		args.MinIdle.Milliseconds(), args.Start}
+	if args.Count > 0 {
+		cmdArgs = append(cmdArgs, "count", args.Count)
+	}
+	cmd := redis.NewXAutoClaimCmd(ctx, cmdArgs...)
+
+	res, err := r.Redis.Do(ctx, cmdArgs...).Slice()
+	if err == nil && len(res) < 2 {
+		err = fmt.Errorf("redisCache: unexpected XAUTOCLAIM reply %v", res)
+	}
+	if err != nil {
+		cmd.SetErr(err)
+		return cmd, err
+	}
+	start, _ := res[0].(string)
+	cmd.SetVal(parseXMessages(res[1]), start)
+
+	return cmd, nil
+}
+
+// parseXMessages Convert a RESP2 array of stream entries into messages, skipping nil entries.
+func parseXMessages(v interface{}) []redis.XMessage {
+	items, _ := v.([]interface{})
+	msgs := make([]redis.XMessage, 0, len(items))
+	for _, item := range items {
+		entry, ok := item.([]interface{})
+		if !ok || len(entry) != 2 {
+			continue
+		}
+		id, _ := entry[0].(string)
+		fields, _ := entry[1].([]interface{})
+		values := make(map[string]interface{}, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			if field, ok := fields[i].(string); ok {
+				values[field] = fields[i+1]
+			}
+		}
+		msgs = append(msgs, redis.XMessage{ID: id, Values: values})
+	}
+
+	return msgs
+}
+
+// XInfoStreamFull Retrieve the full state of a stream, including the pending entries of
+// every consumer group. count limits the entries and PEL items returned; zero uses the
+// server default. The reply is decoded here rather than by the client, which rejects the
+// fields added by Redis 7.0.
+func (r *Redis) XInfoStreamFull(ctx context.Context, stream string, count int64) (*redis.XInfoStreamFull, error) {
+	args := []interface{}{"xinfo", "stream", r.Prefix + stream, "full"}
+	if count > 0 {
+		args = append(args, "count", count)
+	}
+	res, err := r.Redis.Do(ctx, args...).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseXInfoStreamFull(res), nil
+}
+
+// parseXInfoStreamFull Decode an XINFO STREAM FULL reply, ignoring fields it does not know.
+func parseXInfoStreamFull(res []interface{}) *redis.XInfoStreamFull {
+	fields := flatPairs(res)
+	info := &redis.XInfoStreamFull{}
+	info.Length, _ = fields["length"].(int64)
+	info.RadixTreeKeys, _ = fields["radix-tree-keys"].(int64)
+	info.RadixTreeNodes, _ = fields["radix-tree-nodes"].(int64)
+	info.LastGeneratedID, _ = fields["last-generated-id"].(string)
+	info.Entries = parseXMessages(fields["entries"])
+
+	groups, _ := fields["groups"].([]interface{})
+	for _, g := range groups {
+		gf := flatPairs(g)
+		group := redis.XInfoStreamGroup{}
+		group.Name, _ = gf["name"].(string)
+		group.LastDeliveredID, _ = gf["last-delivered-id"].(string)
+		group.PelCount, _ = gf["pel-count"].(int64)
+		pending, _ := gf["pending"].([]interface{})
+		for _, p := range pending {
+			entry, ok := p.([]interface{})
+			if !ok || len(entry) != 4 {
+				continue
+			}
+			item := redis.XInfoStreamGroupPending{DeliveryTime: msTime(entry[2])}
+			item.ID, _ = entry[0].(string)
+			item.Consumer, _ = entry[1].(string)
+			item.DeliveryCount, _ = entry[3].(int64)
+			group.Pending = append(group.Pending, item)
+		}
+
+		consumers, _ := gf["consumers"].([]interface{})
+		for _, c := range consumers {
+			cf := flatPairs(c)
+			consumer := redis.XInfoStreamConsumer{SeenTime: msTime(cf["seen-time"])}
+			consumer.Name, _ = cf["name"].(string)
+			consumer.PelCount, _ = cf["pel-count"].(int64)
+			pending, _ := cf["pending"].([]interface{})
+			for _, p := range pending {
+				entry, ok := p.([]interface{})
+				if !ok || len(entry) != 3 {
+					continue
+				}
+				item := redis.XInfoStreamConsumerPending{DeliveryTime: msTime(entry[1])}
+				item.ID, _ = entry[0].(string)
+				item.DeliveryCount, _ = entry[2].(int64)
+				consumer.Pending = append(consumer.Pending, item)
+			}
+			group.Consumers = append(group.Consumers, consumer)
+		}
+		info.Groups = append(info.Groups, group)
+	}
+
+	return info
+}
+
+// msTime Convert a Unix time in milliseconds from a reply into a time.Time.
+func msTime(v interface{}) time.Time {
+	ms, _ := v.(int64)
+	return time.UnixMilli(ms)
+}
+
+// XDel Remove the given messages from a stream and return how many were deleted.
+func (r *Redis) XDel(ctx context.Context, stream string, ids ...string) (int64, error) {
+	return r.Redis.XDel(ctx, r.Prefix+stream, ids...).Result()
+}
+
+// XTrimMaxLen Trim a stream to at most maxLen messages, evicting the oldest first.
+// With approximate the server may keep a few more messages to trim whole nodes.
+func (r *Redis) XTrimMaxLen(ctx context.Context, stream string, maxLen int64, approximate bool) (int64, error) {
+	if approximate {
+		return r.Redis.XTrimMaxLenApprox(ctx, r.Prefix+stream, maxLen, 0).Result()
+	}
+
+	return r.Redis.XTrimMaxLen(ctx, r.Prefix+stream, maxLen).Result()
+}
+
+// XTrimMinID Remove the messages of a stream whose ID is lower than minID.
+// With approximate the server may keep a few older messages to trim whole nodes.
+func (r *Redis) XTrimMinID(ctx context.Context, stream string, minID string, approximate bool) (int64, error) {
+	if approximate {
+		return r.Redis.XTrimMinIDApprox(ctx, r.Prefix+stream, minID, 0).Result()
+	}
+
+	return r.Redis.XTrimMinID(ctx, r.Prefix+stream, minID).Result()
+}
+
+// XRevRange Retrieve the messages of a stream between start and stop in reverse order.
+// start is the higher ID, e.g. "+", and stop the lower one, e.g. "-".
+func (r *Redis) XRevRange(ctx context.Context, stream, start, stop string) ([]redis.XMessage, error) {
+	return r.Redis.XRevRange(ctx, r.Prefix+stream, start, stop).Result()
+}
+
+// XRevRangeN Retrieve at most count messages of a stream between start and stop in reverse order.
+func (r *Redis) XRevRangeN(ctx context.Context, stream, start, stop string, count int64) ([]redis.XMessage, error) {
+	return r.Redis.XRevRangeN(ctx, r.Prefix+stream, start, stop, count).Result()
+}
+
+// XGroupSetID Move the last delivered ID of a consumer group to id, e.g. "0" to replay the stream.
+func (r *Redis) XGroupSetID(ctx context.Context, stream, group, id string) error {
+	return r.Redis.XGroupSetID(ctx, r.Prefix+stream, group, id).Err()
+}
+
+// XGroupDelConsumer Remove a consumer from a group and return how many pending messages it owned.
+// Those messages are dropped from the pending list, so claim them first if they must be processed.
+func (r *Redis) XGroupDelConsumer(ctx context.Context, stream, group, consumer string) (int64, error) {
+	return r.Redis.XGroupDelConsumer(ctx, r.Prefix+stream, group, consumer).Result()
+}
+
+// XGroupCreateConsumer Create a consumer in a group ahead of its first read.
+// It returns 1 when the consumer was created and 0 when it already existed.
+func (r *Redis) XGroupCreateConsumer(ctx context.Context, stream, group, consumer string) (int64, error) {
+	return r.Redis.XGroupCreateConsumer(ctx, r.Prefix+stream, group, consumer).Result()
+}
+
+// XRange Retrieve the messages of a stream between start and stop, e.g. "-" and "+".
+func (r *Redis) XRange(ctx context.Context, stream, start, stop string) ([]redis.XMessage, error) {
+	return r.Redis.XRange(ctx, r.Prefix+stream, start, stop).Result()
+}
+
+// XRangeN Retrieve at most count messages of a stream between start and stop.
+// To page through a stream, pass "(" followed by the last ID of the previous page as start.
+func (r *Redis) XRangeN(ctx context.Context, stream, start, stop string, count int64) ([]redis.XMessage, error) {
+	return r.Redis.XRangeN(ctx, r.Prefix+stream, start, stop, count).Result()
+}
+
+// XLen Retrieve the number of messages in a stream.
+func (r *Redis) XLen(ctx context.Context, stream string) (int64, error) {
+	return r.Redis.XLen(ctx, r.Prefix+stream).Result()
+}
+
+// StreamAppend Append a message with an auto-generated ID to a stream and return the ID.
+// A positive maxLen trims the stream to roughly that many messages (MAXLEN ~) to keep it bounded.
+func (r *Redis) StreamAppend(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	args := &redis.XAddArgs{
+		Stream: r.Prefix + stream,
+		ID:     "*",
+		Values: values,
+	}
+	if maxLen > 0 {
+		args.MaxLen = maxLen
+		args.Approx = true
+	}
+
+	return r.Redis.XAdd(ctx, args).Result()
+}
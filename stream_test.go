@@ -0,0 +1,253 @@
+package redisCache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestStream Create a stream holding n messages with a consumer group "g" reading it.
+func newTestStream(t *testing.T, r *Redis, stream string, n int) []string {
+	t.Helper()
+	ctx := context.Background()
+	ids := make([]string, n)
+	for i := range ids {
+		id, err := r.StreamAppend(ctx, stream, 0, map[string]interface{}{"n": i})
+		if err != nil {
+			t.Fatalf("StreamAppend: %v", err)
+		}
+		ids[i] = id
+	}
+	if err := r.Redis.XGroupCreate(ctx, r.Prefix+stream, "g", "0").Err(); err != nil {
+		t.Fatalf("XGroupCreate: %v", err)
+	}
+
+	return ids
+}
+
+func TestXAutoClaim(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	m.SetTime(time.Now())
+	ids := newTestStream(t, r, "jobs", 2)
+	r.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "g", Consumer: "c1", Streams: []string{"app:jobs", ">"}, Count: 2, Block: -1})
+
+	args := &redis.XAutoClaimArgs{Stream: "jobs", Group: "g", Consumer: "c2", MinIdle: time.Minute, Start: "0"}
+	_, err := r.XAutoClaim(ctx, args)
+	if err != nil {
+		t.Fatalf("XAutoClaim: %v", err)
+	}
+	if args.Stream != "jobs" {
+		t.Fatal("XAutoClaim modified the caller's arguments")
+	}
+
+	m.SetTime(time.Now().Add(2 * time.Minute))
+	cmd, err := r.XAutoClaim(ctx, args)
+	if err != nil {
+		t.Fatalf("XAutoClaim: %v", err)
+	}
+	msgs, _ := cmd.Val()
+	if len(msgs) != 2 || msgs[0].ID != ids[0] {
+		t.Fatalf("claimed %v, want both messages", msgs)
+	}
+}
+
+func TestXInfoStreamFull(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	// Reply of Redis 7.2, which carries more fields than the client expects.
+	s.on("XINFO", func(c *server.Peer, args []string) {
+		c.WriteLen(18)
+		c.WriteBulk("length")
+		c.WriteInt(2)
+		c.WriteBulk("radix-tree-keys")
+		c.WriteInt(1)
+		c.WriteBulk("radix-tree-nodes")
+		c.WriteInt(2)
+		c.WriteBulk("last-generated-id")
+		c.WriteBulk("1700000000001-0")
+		c.WriteBulk("max-deleted-entry-id")
+		c.WriteBulk("0-0")
+		c.WriteBulk("entries-added")
+		c.WriteInt(2)
+		c.WriteBulk("recorded-first-entry-id")
+		c.WriteBulk("1700000000000-0")
+		c.WriteBulk("entries")
+		c.WriteLen(2)
+		for _, id := range []string{"1700000000000-0", "1700000000001-0"} {
+			c.WriteLen(2)
+			c.WriteBulk(id)
+			c.WriteStrings([]string{"n", "1"})
+		}
+		c.WriteBulk("groups")
+		c.WriteLen(1)
+		c.WriteLen(14)
+		c.WriteBulk("name")
+		c.WriteBulk("g")
+		c.WriteBulk("last-delivered-id")
+		c.WriteBulk("1700000000000-0")
+		c.WriteBulk("entries-read")
+		c.WriteInt(1)
+		c.WriteBulk("lag")
+		c.WriteInt(1)
+		c.WriteBulk("pel-count")
+		c.WriteInt(1)
+		c.WriteBulk("pending")
+		c.WriteLen(1)
+		c.WriteLen(4)
+		c.WriteBulk("1700000000000-0")
+		c.WriteBulk("c1")
+		c.WriteInt(1700000005000)
+		c.WriteInt(2)
+		c.WriteBulk("consumers")
+		c.WriteLen(1)
+		c.WriteLen(10)
+		c.WriteBulk("name")
+		c.WriteBulk("c1")
+		c.WriteBulk("seen-time")
+		c.WriteInt(1700000005000)
+		c.WriteBulk("active-time")
+		c.WriteInt(1700000005000)
+		c.WriteBulk("pel-count")
+		c.WriteInt(1)
+		c.WriteBulk("pending")
+		c.WriteLen(1)
+		c.WriteLen(3)
+		c.WriteBulk("1700000000000-0")
+		c.WriteInt(1700000005000)
+		c.WriteInt(2)
+	})
+
+	info, err := r.XInfoStreamFull(context.Background(), "jobs", 10)
+	if err != nil {
+		t.Fatalf("XInfoStreamFull: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "XINFO"), "stream", "app:jobs", "full", "count", "10")
+	if info.Length != 2 || info.LastGeneratedID != "1700000000001-0" || len(info.Entries) != 2 || info.Entries[0].Values["n"] != "1" {
+		t.Fatalf("XInfoStreamFull = %+v", info)
+	}
+	if len(info.Groups) != 1 || info.Groups[0].PelCount != 1 || len(info.Groups[0].Pending) != 1 {
+		t.Fatalf("groups = %+v", info.Groups)
+	}
+	pending := info.Groups[0].Pending[0]
+	if pending.Consumer != "c1" || pending.DeliveryCount != 2 || !pending.DeliveryTime.Equal(time.UnixMilli(1700000005000)) {
+		t.Fatalf("pending = %+v", pending)
+	}
+	consumers := info.Groups[0].Consumers
+	if len(consumers) != 1 || consumers[0].Name != "c1" || len(consumers[0].Pending) != 1 || consumers[0].Pending[0].DeliveryCount != 2 {
+		t.Fatalf("consumers = %+v", consumers)
+	}
+}
+
+func TestXDel(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	ids := newTestStream(t, r, "jobs", 3)
+
+	n, err := r.XDel(ctx, "jobs", ids[0], ids[2], "9999999999999-0")
+	if err != nil || n != 2 {
+		t.Fatalf("XDel = %d, %v, want 2", n, err)
+	}
+	msgs, _ := r.XRange(ctx, "jobs", "-", "+")
+	if len(msgs) != 1 || msgs[0].ID != ids[1] {
+		t.Fatalf("remaining messages = %v, want only %s", msgs, ids[1])
+	}
+}
+
+func TestXRevRange(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	ids := newTestStream(t, r, "jobs", 3)
+
+	msgs, err := r.XRevRange(ctx, "jobs", "+", "-")
+	if err != nil || len(msgs) != 3 || msgs[0].ID != ids[2] || msgs[2].ID != ids[0] {
+		t.Fatalf("XRevRange = %v, %v, want newest first", msgs, err)
+	}
+	msgs, err = r.XRevRangeN(ctx, "jobs", "+", "-", 2)
+	if err != nil || len(msgs) != 2 || msgs[1].ID != ids[1] {
+		t.Fatalf("XRevRangeN = %v, %v", msgs, err)
+	}
+}
+
+func TestXGroupSetID(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	newTestStream(t, r, "s", 3)
+	s := stubCommands(m)
+	s.on("XGROUP", func(c *server.Peer, args []string) { c.WriteOK() })
+
+	if err := r.XGroupSetID(context.Background(), "s", "g", "0"); err != nil {
+		t.Fatalf("XGroupSetID: %v", err)
+	}
+	equalArgs(t, s.lastCall(t, "XGROUP"), "setid", "app:s", "g", "0")
+}
+
+func TestXGroupDelConsumer(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	newTestStream(t, r, "s", 3)
+	err := r.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group: "g", Consumer: "c", Streams: []string{"app:s", ">"}, Count: 2, Block: -1,
+	}).Err()
+	if err != nil {
+		t.Fatalf("XReadGroup: %v", err)
+	}
+
+	pending, err := r.XGroupDelConsumer(ctx, "s", "g", "c")
+	if err != nil || pending != 2 {
+		t.Fatalf("XGroupDelConsumer = %d, %v, want 2 pending", pending, err)
+	}
+	if n, err := r.Redis.XPending(ctx, "app:s", "g").Result(); err != nil || n.Count != 0 {
+		t.Fatalf("XPending after XGroupDelConsumer = %v, %v, want none", n, err)
+	}
+}
+
+func TestXGroupCreateConsumer(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	newTestStream(t, r, "s", 1)
+
+	if n, err := r.XGroupCreateConsumer(ctx, "s", "g", "c"); err != nil || n != 1 {
+		t.Fatalf("XGroupCreateConsumer = %d, %v, want 1", n, err)
+	}
+	if n, err := r.XGroupCreateConsumer(ctx, "s", "g", "c"); err != nil || n != 0 {
+		t.Fatalf("XGroupCreateConsumer of an existing consumer = %d, %v, want 0", n, err)
+	}
+	consumers, err := r.Redis.Do(ctx, "xinfo", "consumers", "app:s", "g").Slice()
+	if err != nil || len(consumers) != 1 || flatPairs(consumers[0])["name"] != "c" {
+		t.Fatalf("XINFO CONSUMERS = %v, %v", consumers, err)
+	}
+}
+
+func TestXRangePagination(t *testing.T) {
+	r, _ := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	ids := newTestStream(t, r, "s", 5)
+
+	if n, err := r.XLen(ctx, "s"); err != nil || n != 5 {
+		t.Fatalf("XLen = %d, %v, want 5", n, err)
+	}
+	all, err := r.XRange(ctx, "s", "-", "+")
+	if err != nil || len(all) != 5 || all[4].ID != ids[4] {
+		t.Fatalf("XRange = %v, %v", all, err)
+	}
+
+	var paged []string
+	start := "-"
+	for {
+		page, err := r.XRangeN(ctx, "s", start, "+", 2)
+		if err != nil {
+			t.Fatalf("XRangeN: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, msg := range page {
+			paged = append(paged, msg.ID)
+		}
+		start = "(" + page[len(page)-1].ID
+	}
+	equalArgs(t, paged, ids...)
+}
@@ -0,0 +1,148 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// ErrChaos is returned by ChaosStore operations chosen to fail.
+var ErrChaos = errors.New("testutil: injected failure")
+
+// ChaosStore fails a random fraction of the operations of the wrapped store.
+type ChaosStore struct {
+	inner    cache.Store
+	failRate float64
+	mu       *sync.Mutex
+	rand     *rand.Rand
+}
+
+// NewChaosStore Wrap inner so that each operation fails with probability failRate.
+// Failed reads return the default value, failed writes return false or ErrChaos.
+func NewChaosStore(inner cache.Store, failRate float64) cache.Store {
+	return &ChaosStore{
+		inner:    inner,
+		failRate: failRate,
+		mu:       &sync.Mutex{},
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *ChaosStore) fail() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64() < s.failRate
+}
+
+// WithContext Return a copy of the store whose operations use ctx and share its failure rate.
+func (s *ChaosStore) WithContext(ctx context.Context) cache.Store {
+	return &ChaosStore{inner: s.inner.WithContext(ctx), failRate: s.failRate, mu: s.mu, rand: s.rand}
+}
+
+// Get Retrieve an item from the cache by key.
+func (s *ChaosStore) Get(key string, def interface{}) interface{} {
+	if s.fail() {
+		return def
+	}
+	return s.inner.Get(key, def)
+}
+
+// GetBool Retrieve an item from the cache by key as a boolean.
+func (s *ChaosStore) GetBool(key string, def bool) bool {
+	if s.fail() {
+		return def
+	}
+	return s.inner.GetBool(key, def)
+}
+
+// GetInt Retrieve an item from the cache by key as an integer.
+func (s *ChaosStore) GetInt(key string, def int) int {
+	if s.fail() {
+		return def
+	}
+	return s.inner.GetInt(key, def)
+}
+
+// GetString Retrieve an item from the cache by key as a string.
+func (s *ChaosStore) GetString(key string, def string) string {
+	if s.fail() {
+		return def
+	}
+	return s.inner.GetString(key, def)
+}
+
+// Has Check an item exists in the cache.
+func (s *ChaosStore) Has(key string) bool {
+	if s.fail() {
+		return false
+	}
+	return s.inner.Has(key)
+}
+
+// Put Store an item in the cache for a given number of seconds.
+func (s *ChaosStore) Put(key string, value interface{}, seconds time.Duration) error {
+	if s.fail() {
+		return ErrChaos
+	}
+	return s.inner.Put(key, value, seconds)
+}
+
+// Pull Retrieve an item from the cache and delete it.
+func (s *ChaosStore) Pull(key string, def interface{}) interface{} {
+	if s.fail() {
+		return def
+	}
+	return s.inner.Pull(key, def)
+}
+
+// Add Store an item in the cache if the key does not exist.
+func (s *ChaosStore) Add(key string, value interface{}, seconds time.Duration) bool {
+	if s.fail() {
+		return false
+	}
+	return s.inner.Add(key, value, seconds)
+}
+
+// Remember Get an item from the cache, or execute the given Closure and store the result.
+func (s *ChaosStore) Remember(key string, ttl time.Duration, callback func() interface{}) (interface{}, error) {
+	if s.fail() {
+		return nil, ErrChaos
+	}
+	return s.inner.Remember(key, ttl, callback)
+}
+
+// RememberForever Get an item from the cache, or execute the given Closure and store the result forever.
+func (s *ChaosStore) RememberForever(key string, callback func() interface{}) (interface{}, error) {
+	if s.fail() {
+		return nil, ErrChaos
+	}
+	return s.inner.RememberForever(key, callback)
+}
+
+// Forever Store an item in the cache indefinitely.
+func (s *ChaosStore) Forever(key string, value interface{}) bool {
+	if s.fail() {
+		return false
+	}
+	return s.inner.Forever(key, value)
+}
+
+// Forget Remove an item from the cache.
+func (s *ChaosStore) Forget(key string) bool {
+	if s.fail() {
+		return false
+	}
+	return s.inner.Forget(key)
+}
+
+// Flush Remove all items from the cache.
+func (s *ChaosStore) Flush() bool {
+	if s.fail() {
+		return false
+	}
+	return s.inner.Flush()
+}
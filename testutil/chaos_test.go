@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"testing"
+)
+
+func TestChaosStoreNeverFails(t *testing.T) {
+	store := NewChaosStore(NewMockStore(), 0)
+
+	for i := 0; i < 100; i++ {
+		if err := store.Put("k", "v", 0); err != nil {
+			t.Fatalf("Put = %v, want no failure at rate 0", err)
+		}
+		if got := store.GetString("k", "def"); got != "v" {
+			t.Fatalf("GetString = %q, want v", got)
+		}
+	}
+}
+
+func TestChaosStoreAlwaysFails(t *testing.T) {
+	inner := NewMockStore()
+	_ = inner.Put("k", "v", 0)
+	store := NewChaosStore(inner, 1)
+
+	for i := 0; i < 100; i++ {
+		if err := store.Put("k", "other", 0); err != ErrChaos {
+			t.Fatalf("Put = %v, want ErrChaos", err)
+		}
+		if got := store.Get("k", "def"); got != "def" {
+			t.Fatalf("Get = %v, want the default", got)
+		}
+		if got := store.GetInt("k", 7); got != 7 {
+			t.Fatalf("GetInt = %d, want the default", got)
+		}
+		if store.Has("k") {
+			t.Fatal("Has = true, want false")
+		}
+		if _, err := store.Remember("k", 0, func() interface{} { return "x" }); err != ErrChaos {
+			t.Fatalf("Remember = %v, want ErrChaos", err)
+		}
+	}
+	if got := inner.GetString("k", ""); got != "v" {
+		t.Fatalf("inner value = %q, want it untouched", got)
+	}
+}
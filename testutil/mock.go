@@ -0,0 +1,184 @@
+package testutil
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+	"github.com/sujit-baniya/redisCache"
+)
+
+type mockItem struct {
+	value   interface{}
+	expires time.Time
+}
+
+// MockStore is an in-memory cache.Store whose expiry is driven by a Clock.
+type MockStore struct {
+	Clock redisCache.Clock
+
+	mu    *sync.Mutex
+	items map[string]mockItem
+}
+
+// NewMockStore Create an empty MockStore. Without a clock it uses a ManualClock
+// starting at the current time, so expiry only happens when the test advances it.
+func NewMockStore(clock ...redisCache.Clock) *MockStore {
+	var c redisCache.Clock = redisCache.NewManualClock(time.Now())
+	if len(clock) > 0 && clock[0] != nil {
+		c = clock[0]
+	}
+
+	return &MockStore{Clock: c, mu: &sync.Mutex{}, items: map[string]mockItem{}}
+}
+
+func (m *MockStore) WithContext(ctx context.Context) cache.Store {
+	return m
+}
+
+// load Return the live item stored at key; callers must hold the lock.
+func (m *MockStore) load(key string) (mockItem, bool) {
+	item, ok := m.items[key]
+	if !ok {
+		return item, false
+	}
+	if !item.expires.IsZero() && !m.Clock.Now().Before(item.expires) {
+		delete(m.items, key)
+		return item, false
+	}
+
+	return item, true
+}
+
+func (m *MockStore) store(key string, value interface{}, ttl time.Duration) {
+	item := mockItem{value: value}
+	if ttl > 0 {
+		item.expires = m.Clock.Now().Add(ttl)
+	}
+	m.items[key] = item
+}
+
+// Get Retrieve an item from the cache by key.
+func (m *MockStore) Get(key string, def interface{}) interface{} {
+	m.mu.Lock()
+	item, ok := m.load(key)
+	m.mu.Unlock()
+	if !ok {
+		if fn, isFn := def.(func() interface{}); isFn {
+			return fn()
+		}
+		return def
+	}
+
+	return item.value
+}
+
+func (m *MockStore) GetBool(key string, def bool) bool {
+	switch v := m.Get(key, def).(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+
+	return def
+}
+
+func (m *MockStore) GetInt(key string, def int) int {
+	switch v := m.Get(key, def).(type) {
+	case int:
+		return v
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+
+	return def
+}
+
+func (m *MockStore) GetString(key string, def string) string {
+	if v, ok := m.Get(key, def).(string); ok {
+		return v
+	}
+
+	return def
+}
+
+// Has Check an item exists in the cache.
+func (m *MockStore) Has(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.load(key)
+	return ok
+}
+
+// Put Store an item in the cache for a given number of seconds.
+func (m *MockStore) Put(key string, value interface{}, seconds time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store(key, value, seconds)
+	return nil
+}
+
+// Pull Retrieve an item from the cache and delete it.
+func (m *MockStore) Pull(key string, def interface{}) interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.load(key)
+	if !ok {
+		return def
+	}
+	delete(m.items, key)
+	return item.value
+}
+
+// Add Store an item in the cache if the key does not exist.
+func (m *MockStore) Add(key string, value interface{}, seconds time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.load(key); ok {
+		return false
+	}
+	m.store(key, value, seconds)
+	return true
+}
+
+// Remember Get an item from the cache, or execute the given Closure and store the result.
+func (m *MockStore) Remember(key string, ttl time.Duration, callback func() interface{}) (interface{}, error) {
+	if val := m.Get(key, nil); val != nil {
+		return val, nil
+	}
+	val := callback()
+	return val, m.Put(key, val, ttl)
+}
+
+// RememberForever Get an item from the cache, or execute the given Closure and store the result forever.
+func (m *MockStore) RememberForever(key string, callback func() interface{}) (interface{}, error) {
+	return m.Remember(key, 0, callback)
+}
+
+// Forever Store an item in the cache indefinitely.
+func (m *MockStore) Forever(key string, value interface{}) bool {
+	return m.Put(key, value, 0) == nil
+}
+
+// Forget Remove an item from the cache.
+func (m *MockStore) Forget(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, key)
+	return true
+}
+
+// Flush Remove all items from the cache.
+func (m *MockStore) Flush() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = map[string]mockItem{}
+	return true
+}
@@ -0,0 +1,31 @@
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sujit-baniya/redisCache"
+)
+
+func TestMockStoreExpiry(t *testing.T) {
+	clock := redisCache.NewManualClock(time.Now())
+	store := NewMockStore(clock)
+
+	_ = store.Put("session", "abc", 10*time.Second)
+	_ = store.Put("config", "x", 0)
+	_ = clock.Advance(9 * time.Second)
+	if got := store.GetString("session", ""); got != "abc" {
+		t.Fatalf("session before expiry = %q, want abc", got)
+	}
+
+	_ = clock.Advance(time.Second)
+	if store.Has("session") {
+		t.Fatal("session still present after its TTL")
+	}
+	if !store.Has("config") {
+		t.Fatal("item without TTL expired")
+	}
+	if err := clock.Advance(-time.Second); err != redisCache.ErrNegativeDuration {
+		t.Fatalf("Advance(-1s) = %v, want ErrNegativeDuration", err)
+	}
+}
@@ -0,0 +1,119 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// SerializedStore runs every operation of the wrapped store under a single mutex.
+type SerializedStore struct {
+	mu    *sync.Mutex
+	inner cache.Store
+}
+
+// NewSerializedStore Wrap inner so that all operations run one at a time,
+// giving integration tests a deterministic order of execution.
+func NewSerializedStore(inner cache.Store) cache.Store {
+	return &SerializedStore{mu: &sync.Mutex{}, inner: inner}
+}
+
+// WithContext Return a copy of the store whose operations use ctx and share its mutex.
+func (s *SerializedStore) WithContext(ctx context.Context) cache.Store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &SerializedStore{mu: s.mu, inner: s.inner.WithContext(ctx)}
+}
+
+// Get Retrieve an item from the cache by key.
+func (s *SerializedStore) Get(key string, def interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Get(key, def)
+}
+
+// GetBool Retrieve an item from the cache by key as a boolean.
+func (s *SerializedStore) GetBool(key string, def bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.GetBool(key, def)
+}
+
+// GetInt Retrieve an item from the cache by key as an integer.
+func (s *SerializedStore) GetInt(key string, def int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.GetInt(key, def)
+}
+
+// GetString Retrieve an item from the cache by key as a string.
+func (s *SerializedStore) GetString(key string, def string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.GetString(key, def)
+}
+
+// Has Check an item exists in the cache.
+func (s *SerializedStore) Has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Has(key)
+}
+
+// Put Store an item in the cache for a given number of seconds.
+func (s *SerializedStore) Put(key string, value interface{}, seconds time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Put(key, value, seconds)
+}
+
+// Pull Retrieve an item from the cache and delete it.
+func (s *SerializedStore) Pull(key string, def interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Pull(key, def)
+}
+
+// Add Store an item in the cache if the key does not exist.
+func (s *SerializedStore) Add(key string, value interface{}, seconds time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Add(key, value, seconds)
+}
+
+// Remember Get an item from the cache, or execute the given Closure and store the result.
+func (s *SerializedStore) Remember(key string, ttl time.Duration, callback func() interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Remember(key, ttl, callback)
+}
+
+// RememberForever Get an item from the cache, or execute the given Closure and store the result forever.
+func (s *SerializedStore) RememberForever(key string, callback func() interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.RememberForever(key, callback)
+}
+
+// Forever Store an item in the cache indefinitely.
+func (s *SerializedStore) Forever(key string, value interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Forever(key, value)
+}
+
+// Forget Remove an item from the cache.
+func (s *SerializedStore) Forget(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Forget(key)
+}
+
+// Flush Remove all items from the cache.
+func (s *SerializedStore) Flush() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inner.Flush()
+}
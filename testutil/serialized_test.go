@@ -0,0 +1,52 @@
+package testutil
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// overlapStore records the highest number of Gets running at once.
+type overlapStore struct {
+	cache.Store
+	active, peak int32
+}
+
+func (s *overlapStore) Get(key string, def interface{}) interface{} {
+	n := atomic.AddInt32(&s.active, 1)
+	defer atomic.AddInt32(&s.active, -1)
+	for {
+		peak := atomic.LoadInt32(&s.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&s.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	return s.Store.Get(key, def)
+}
+
+func TestSerializedStore(t *testing.T) {
+	inner := &overlapStore{Store: NewMockStore()}
+	_ = inner.Put("k", "v", 0)
+	store := NewSerializedStore(inner)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := store.Get("k", nil); got != "v" {
+				t.Errorf("Get = %v, want v", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inner.peak != 1 {
+		t.Fatalf("%d Gets ran at once, want 1", inner.peak)
+	}
+}
@@ -0,0 +1,110 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/sujit-baniya/framework/contracts/cache"
+)
+
+// TTLAwareStore is a cache.Store that reports items read close to their expiry.
+type TTLAwareStore struct {
+	cache.Store
+	redis    *Redis
+	onLowTTL func(key string, remaining time.Duration)
+}
+
+// NewTTLAware Wrap inner so that every hit checks, in the background, the remaining TTL of
+// the item and calls onLowTTL when it is below Config.LowTTLThreshold, giving the application
+// a chance to refresh it ahead of expiry. TTLs are only checked when inner is a store created by New.
+func NewTTLAware(inner cache.Store, onLowTTL func(key string, remaining time.Duration)) cache.Store {
+	r, _ := inner.(*Redis)
+	return &TTLAwareStore{
+		Store:    inner,
+		redis:    r,
+		onLowTTL: onLowTTL,
+	}
+}
+
+func (s *TTLAwareStore) WithContext(ctx context.Context) cache.Store {
+	return NewTTLAware(s.Store.WithContext(ctx), s.onLowTTL)
+}
+
+// Get Retrieve an item from the cache by key.
+func (s *TTLAwareStore) Get(key string, def interface{}) interface{} {
+	missing := &struct{}{}
+	val := s.Store.Get(key, missing)
+	if val == interface{}(missing) {
+		if fn, ok := def.(func() interface{}); ok {
+			return fn()
+		}
+		return def
+	}
+	if s.redis != nil && s.onLowTTL != nil {
+		go s.checkTTL(key)
+	}
+
+	return val
+}
+
+func (s *TTLAwareStore) GetBool(key string, def bool) bool {
+	switch s.Get(key, def) {
+	case "1", "true", true:
+		return true
+	case "0", "false", false:
+		return false
+	}
+
+	return def
+}
+
+func (s *TTLAwareStore) GetInt(key string, def int) int {
+	switch res := s.Get(key, def).(type) {
+	case int:
+		return res
+	case string:
+		if i, err := strconv.Atoi(res); err == nil {
+			return i
+		}
+	}
+
+	return def
+}
+
+func (s *TTLAwareStore) GetString(key string, def string) string {
+	if res, ok := s.Get(key, def).(string); ok {
+		return res
+	}
+
+	return def
+}
+
+// Remember Get an item from the cache, or execute the given Closure and store the result.
+func (s *TTLAwareStore) Remember(key string, ttl time.Duration, callback func() interface{}) (interface{}, error) {
+	if val := s.Get(key, nil); val != nil {
+		return val, nil
+	}
+	val := callback()
+	if err := s.Put(key, val, ttl); err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
+
+// RememberForever Get an item from the cache, or execute the given Closure and store the result forever.
+func (s *TTLAwareStore) RememberForever(key string, callback func() interface{}) (interface{}, error) {
+	return s.Remember(key, 0, callback)
+}
+
+func (s *TTLAwareStore) checkTTL(key string) {
+	r := s.redis
+	remaining, err := r.Redis.PTTL(r.ctx, r.Prefix+key).Result()
+	if err != nil || remaining <= 0 {
+		return
+	}
+	if remaining < r.config.LowTTLThreshold {
+		s.onLowTTL(key, remaining)
+	}
+}
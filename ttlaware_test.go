@@ -0,0 +1,41 @@
+package redisCache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLAwareOnLowTTL(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:", LowTTLThreshold: 30 * time.Second})
+	low := make(chan string, 2)
+	store := NewTTLAware(r, func(key string, remaining time.Duration) {
+		low <- key
+	})
+	_ = r.Put("fresh", "v", time.Hour)
+	_ = r.Put("expiring", "v", time.Minute)
+
+	if got := store.Get("fresh", nil); got != "v" {
+		t.Fatalf("Get fresh = %v, want v", got)
+	}
+	m.FastForward(45 * time.Second)
+	if got := store.GetString("expiring", ""); got != "v" {
+		t.Fatalf("GetString expiring = %q, want v", got)
+	}
+	select {
+	case key := <-low:
+		if key != "expiring" {
+			t.Fatalf("onLowTTL called for %q, want expiring", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("onLowTTL was not called for the expiring item")
+	}
+	select {
+	case key := <-low:
+		t.Fatalf("onLowTTL called again for %q", key)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if got := store.Get("missing", "def"); got != "def" {
+		t.Fatalf("Get missing = %v, want def", got)
+	}
+}
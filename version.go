@@ -0,0 +1,84 @@
+package redisCache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsVersionSupported Check whether the server version is at least minMajor.minMinor.
+func (r *Redis) IsVersionSupported(ctx context.Context, minMajor, minMinor int) (bool, error) {
+	version, err := r.serverVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return versionAtLeast(version, minMajor, minMinor)
+}
+
+// serverVersion Retrieve redis_version from the server section of INFO.
+func (r *Redis) serverVersion(ctx context.Context) (string, error) {
+	info, err := r.Redis.Info(ctx, "server").Result()
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "redis_version:") {
+			return strings.TrimPrefix(line, "redis_version:"), nil
+		}
+	}
+
+	return "", fmt.Errorf("redisCache: redis_version missing from INFO server")
+}
+
+// versionAtLeast Check whether a "major.minor.patch" version string is at least minMajor.minMinor.
+func versionAtLeast(version string, minMajor, minMinor int) (bool, error) {
+	major, minor, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	return major > minMajor || (major == minMajor && minor >= minMinor), nil
+}
+
+// parseVersion Extract the major and minor numbers of a "major.minor[.patch]" version string.
+func parseVersion(version string) (int, int, error) {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("redisCache: invalid version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("redisCache: invalid version %q", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("redisCache: invalid version %q", version)
+	}
+
+	return major, minor, nil
+}
+
+// checkMinVersion Return ErrVersionNotSupported when the server, as detected by New, is older
+// than minVersion or its version could not be determined.
+func (r *Redis) checkMinVersion(minVersion string) error {
+	major, minor, err := parseVersion(minVersion + ".0")
+	if err != nil {
+		return err
+	}
+	version := r.features.Version
+	if version == "" {
+		return fmt.Errorf("%w: server version unknown, need %s", ErrVersionNotSupported, minVersion)
+	}
+	ok, err := versionAtLeast(version, major, minor)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: server is %s, need %s", ErrVersionNotSupported, version, minVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package redisCache
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		ok           bool
+	}{
+		{"7.2.4", 7, 2, true},
+		{"6.0", 6, 0, true},
+		{" 255.255.255\r", 255, 255, true},
+		{"7", 0, 0, false},
+		{"", 0, 0, false},
+		{"x.1.0", 0, 0, false},
+		{"7.y", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, err := parseVersion(tt.version)
+		if (err == nil) != tt.ok || major != tt.major || minor != tt.minor {
+			t.Errorf("parseVersion(%q) = %d, %d, %v", tt.version, major, minor, err)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		want         bool
+	}{
+		{"7.0.0", 7, 0, true},
+		{"7.2.4", 6, 2, true},
+		{"6.2.14", 7, 0, false},
+		{"6.0.9", 6, 2, false},
+		{"10.0.0", 9, 9, true},
+	}
+	for _, tt := range tests {
+		got, err := versionAtLeast(tt.version, tt.major, tt.minor)
+		if err != nil || got != tt.want {
+			t.Errorf("versionAtLeast(%q, %d, %d) = %v, %v, want %v", tt.version, tt.major, tt.minor, got, err, tt.want)
+		}
+	}
+	if _, err := versionAtLeast("unknown", 6, 0); err == nil {
+		t.Error("versionAtLeast accepted an invalid version")
+	}
+}
+
+func TestCheckMinVersion(t *testing.T) {
+	r := &Redis{features: featuresFor("6.2.14", true)}
+
+	if err := r.checkMinVersion("6.2"); err != nil {
+		t.Fatalf("checkMinVersion(6.2) = %v", err)
+	}
+	if err := r.checkMinVersion("7.0"); !errors.Is(err, ErrVersionNotSupported) {
+		t.Fatalf("checkMinVersion(7.0) = %v, want ErrVersionNotSupported", err)
+	}
+	r.features = FeatureSet{}
+	if err := r.checkMinVersion("6.0"); !errors.Is(err, ErrVersionNotSupported) {
+		t.Fatalf("checkMinVersion with an unknown version = %v, want ErrVersionNotSupported", err)
+	}
+}
@@ -0,0 +1,141 @@
+package redisCache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ZRangeStore Store a range of the sorted set at src into dst.
+// The Key field of opt is ignored in favour of src.
+func (r *Redis) ZRangeStore(ctx context.Context, dst, src string, opt *redis.ZRangeArgs) (int64, error) {
+	var z redis.ZRangeArgs
+	if opt != nil {
+		z = *opt
+	}
+	z.Key = r.Prefix + src
+
+	return r.Redis.ZRangeStore(ctx, r.Prefix+dst, z).Result()
+}
+
+// ZMPop Pop up to count members from the first non-empty sorted set among keys.
+// direction is "MIN" or "MAX". It returns the key the members were popped from,
+// or redis.Nil when every set is empty. Servers older than Redis 7.0 fall back to
+// popping each set in turn, which is not atomic across keys.
+func (r *Redis) ZMPop(ctx context.Context, count int64, direction string, keys ...string) (string, []redis.Z, error) {
+	if !r.features.ZMPop {
+		return r.zmpopFallback(ctx, count, direction, keys)
+	}
+
+	args := []interface{}{"zmpop", len(keys)}
+	for _, key := range keys {
+		args = append(args, r.Prefix+key)
+	}
+	args = append(args, direction, "count", count)
+
+	res, err := r.Redis.Do(ctx, args...).Slice()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(res) != 2 {
+		return "", nil, redis.Nil
+	}
+	key, _ := res[0].(string)
+	items, _ := res[1].([]interface{})
+	members := make([]redis.Z, 0, len(items))
+	for _, item := range items {
+		pair, ok := item.([]interface{})
+		if !ok || len(pair) != 2 {
+			continue
+		}
+		score, err := parseScore(pair[1])
+		if err != nil {
+			return "", nil, err
+		}
+		members = append(members, redis.Z{Member: pair[0], Score: score})
+	}
+
+	return strings.TrimPrefix(key, r.Prefix), members, nil
+}
+
+func (r *Redis) zmpopFallback(ctx context.Context, count int64, direction string, keys []string) (string, []redis.Z, error) {
+	for _, key := range keys {
+		var members []redis.Z
+		var err error
+		if strings.EqualFold(direction, "max") {
+			members, err = r.Redis.ZPopMax(ctx, r.Prefix+key, count).Result()
+		} else {
+			members, err = r.Redis.ZPopMin(ctx, r.Prefix+key, count).Result()
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if len(members) > 0 {
+			return key, members, nil
+		}
+	}
+
+	return "", nil, redis.Nil
+}
+
+// parseScore Convert a sorted set score reply into a float64.
+func parseScore(v interface{}) (float64, error) {
+	switch s := v.(type) {
+	case string:
+		return strconv.ParseFloat(s, 64)
+	case int64:
+		return float64(s), nil
+	case float64:
+		return s, nil
+	}
+
+	return 0, fmt.Errorf("redisCache: unexpected score type %T", v)
+}
+
+// ZRangeArgs Retrieve a range of a sorted set using the unified ZRANGE syntax of Redis 6.2,
+// which covers index, score and lex ranges in either direction.
+func (r *Redis) ZRangeArgs(ctx context.Context, z redis.ZRangeArgs) ([]string, error) {
+	z.Key = r.Prefix + z.Key
+	return r.Redis.ZRangeArgs(ctx, z).Result()
+}
+
+// ZRangeArgsWithScores Retrieve a range of a sorted set with scores using the unified ZRANGE syntax.
+func (r *Redis) ZRangeArgsWithScores(ctx context.Context, z redis.ZRangeArgs) ([]redis.Z, error) {
+	z.Key = r.Prefix + z.Key
+	return r.Redis.ZRangeArgsWithScores(ctx, z).Result()
+}
+
+// ZAddBatch Add members to the sorted set at key in chunks of Config.MaxBatchSize members per
+// ZADD, sent in one pipeline, and return how many new members were added. Large sets cost a
+// single round trip without any one command blocking the server for long.
+func (r *Redis) ZAddBatch(ctx context.Context, key string, members ...redis.Z) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	size := r.config.MaxBatchSize
+	cmds := make([]*redis.IntCmd, 0, len(members)/size+1)
+	_, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for start := 0; start < len(members); start += size {
+			end := start + size
+			if end > len(members) {
+				end = len(members)
+			}
+			chunk := make([]*redis.Z, end-start)
+			for i := range chunk {
+				chunk[i] = &members[start+i]
+			}
+			cmds = append(cmds, pipe.ZAdd(ctx, r.Prefix+key, chunk...))
+		}
+		return nil
+	})
+
+	var added int64
+	for _, cmd := range cmds {
+		added += cmd.Val()
+	}
+
+	return added, err
+}
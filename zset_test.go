@@ -0,0 +1,139 @@
+package redisCache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestZRangeStore(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	s.on("ZRANGESTORE", func(c *server.Peer, args []string) { c.WriteInt(2) })
+
+	n, err := r.ZRangeStore(context.Background(), "top", "scores", &redis.ZRangeArgs{
+		Key:   "ignored",
+		Start: 0,
+		Stop:  1,
+		Rev:   true,
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("ZRangeStore = %d, %v, want 2", n, err)
+	}
+	equalArgs(t, s.lastCall(t, "ZRANGESTORE"), "app:top", "app:scores", "0", "1", "rev")
+}
+
+func TestZMPop(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	s.on("ZMPOP", func(c *server.Peer, args []string) {
+		c.WriteLen(2)
+		c.WriteBulk("app:b")
+		c.WriteLen(2)
+		c.WriteStrings([]string{"x", "1"})
+		c.WriteStrings([]string{"y", "2.5"})
+	})
+	ctx := context.Background()
+
+	key, members, err := r.ZMPop(ctx, 2, "MIN", "a", "b")
+	if err != nil {
+		t.Fatalf("ZMPop: %v", err)
+	}
+	if key != "b" || len(members) != 2 || members[1].Member != "y" || members[1].Score != 2.5 {
+		t.Fatalf("ZMPop = %q, %v", key, members)
+	}
+	equalArgs(t, s.lastCall(t, "ZMPOP"), "2", "app:a", "app:b", "MIN", "count", "2")
+
+	s.on("ZMPOP", func(c *server.Peer, args []string) { c.WriteNull() })
+	if _, _, err := r.ZMPop(ctx, 1, "MIN", "a"); err != redis.Nil {
+		t.Fatalf("ZMPop on empty sets = %v, want redis.Nil", err)
+	}
+}
+
+func TestZRangeArgs(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	ctx := context.Background()
+	for i, member := range []string{"a", "b", "c", "d"} {
+		_, _ = m.ZAdd("app:scores", float64(i+1), member)
+	}
+
+	members, err := r.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "scores", Start: "(1", Stop: "+inf", ByScore: true, Count: 2})
+	if err != nil || len(members) != 2 || members[0] != "b" || members[1] != "c" {
+		t.Fatalf("ZRangeArgs by score = %v, %v, want [b c]", members, err)
+	}
+	withScores, err := r.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{Key: "scores", Start: 0, Stop: 1, Rev: true})
+	if err != nil || len(withScores) != 2 || withScores[0].Member != "d" || withScores[0].Score != 4 {
+		t.Fatalf("ZRangeArgsWithScores = %v, %v, want d first", withScores, err)
+	}
+}
+
+func TestZMPopFallback(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	r.features.ZMPop = false
+	ctx := context.Background()
+	_, _ = m.ZAdd("app:b", 1, "x")
+	_, _ = m.ZAdd("app:b", 2.5, "y")
+	_, _ = m.ZAdd("app:b", 3, "z")
+
+	key, members, err := r.ZMPop(ctx, 2, "MAX", "a", "b")
+	if err != nil {
+		t.Fatalf("ZMPop: %v", err)
+	}
+	if key != "b" || len(members) != 2 || members[0].Member != "z" || members[1].Score != 2.5 {
+		t.Fatalf("ZMPop = %q, %v, want b [z y]", key, members)
+	}
+
+	_, _, _ = r.ZMPop(ctx, 5, "MIN", "a", "b")
+	if _, _, err := r.ZMPop(ctx, 1, "MIN", "a", "b"); err != redis.Nil {
+		t.Fatalf("ZMPop on empty sets = %v, want redis.Nil", err)
+	}
+}
+
+func TestZAddBatch(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:", MaxBatchSize: 2})
+	s := stubCommands(m)
+	ctx := context.Background()
+	_, _ = m.ZAdd("app:z", 9, "a")
+
+	members := []redis.Z{{Member: "a", Score: 1}, {Member: "b", Score: 2}, {Member: "c", Score: 3}, {Member: "d", Score: 4}, {Member: "e", Score: 5}}
+	added, err := r.ZAddBatch(ctx, "z", members...)
+	if err != nil || added != 4 {
+		t.Fatalf("ZAddBatch = %d, %v, want 4 new members", added, err)
+	}
+	if n := len(s.called("ZADD")); n != 3 {
+		t.Fatalf("ZADD sent %d times, want 3 chunks of at most 2 members", n)
+	}
+	if score, _ := m.ZScore("app:z", "a"); score != 1 {
+		t.Fatalf("score of a = %v, want it updated to 1", score)
+	}
+	if n, _ := r.Redis.ZCard(ctx, "app:z").Result(); n != 5 {
+		t.Fatalf("ZCard = %d, want 5", n)
+	}
+	if added, err := r.ZAddBatch(ctx, "z"); err != nil || added != 0 {
+		t.Fatalf("ZAddBatch without members = %d, %v", added, err)
+	}
+}
+
+func TestZAddBatchLarge(t *testing.T) {
+	r, m := newTestStore(t, Config{Prefix: "app:"})
+	s := stubCommands(m)
+	ctx := context.Background()
+
+	members := make([]redis.Z, 10000)
+	for i := range members {
+		members[i] = redis.Z{Member: "m" + strconv.Itoa(i), Score: float64(i)}
+	}
+	added, err := r.ZAddBatch(ctx, "z", members...)
+	if err != nil || added != 10000 {
+		t.Fatalf("ZAddBatch = %d, %v, want 10000 new members", added, err)
+	}
+	if n := len(s.called("ZADD")); n != 10 {
+		t.Fatalf("ZADD sent %d times, want 10 chunks of the default 1000 members", n)
+	}
+	if n, _ := r.Redis.ZCard(ctx, "app:z").Result(); n != 10000 {
+		t.Fatalf("ZCard = %d, want 10000", n)
+	}
+}